@@ -0,0 +1,88 @@
+package hn
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// batcher coalesces the item requests of ItemService.Get into waves (see WithBatchWindow).
+// The requests arriving within the window are queued, and then dispatched in waves of up to size requests,
+// waiting for the window between the waves. The dispatcher goroutine runs only while the queue is not empty.
+type batcher struct {
+	window time.Duration
+	size   int
+
+	mu      sync.Mutex
+	queue   []*batchCall
+	running bool
+}
+
+// batchCall is a queued request of a batcher.
+type batchCall struct {
+	ctx  context.Context
+	id   uint
+	done chan struct{}
+	item Item
+	err  error
+}
+
+func newBatcher(window time.Duration, size int) *batcher {
+	return &batcher{window: window, size: size}
+}
+
+// do queues the request for the item with the specified ID, and waits until it's sent with fetch in one of the waves.
+func (b *batcher) do(ctx context.Context, id uint, fetch func(context.Context, uint) (Item, error)) (Item, error) {
+	call := &batchCall{ctx: ctx, id: id, done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.queue = append(b.queue, call)
+
+	if !b.running {
+		b.running = true
+		go b.dispatch(fetch)
+	}
+
+	b.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.item, call.err
+	case <-ctx.Done():
+		// The call stays in the queue, but it's skipped by the dispatcher.
+		return Item{}, ctx.Err()
+	}
+}
+
+// dispatch sends the queued requests in waves until the queue is empty.
+func (b *batcher) dispatch(fetch func(context.Context, uint) (Item, error)) {
+	for {
+		time.Sleep(b.window)
+
+		b.mu.Lock()
+
+		if len(b.queue) == 0 {
+			b.running = false
+			b.mu.Unlock()
+
+			return
+		}
+
+		n := min(len(b.queue), b.size)
+		wave := b.queue[:n:n]
+		b.queue = b.queue[n:]
+
+		b.mu.Unlock()
+
+		// The next wave starts after the window, without waiting for the slow requests of this one.
+		for _, call := range wave {
+			go func() {
+				defer close(call.done)
+
+				if call.err = call.ctx.Err(); call.err == nil {
+					call.item, call.err = fetch(call.ctx, call.id)
+				}
+			}()
+		}
+	}
+}
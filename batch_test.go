@@ -0,0 +1,75 @@
+package hn_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestBatchWindow(t *testing.T) {
+	const (
+		window = 100 * time.Millisecond
+		size   = 2
+	)
+
+	var (
+		mu       sync.Mutex
+		arrivals []time.Time
+	)
+
+	f := &fixture{items: itemsOf(newStory(1, 1), newStory(2, 1), newStory(3, 1), newStory(4, 1), newStory(5, 1), newStory(6, 1))}
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		if strings.HasPrefix(r.URL.Path, "/item/") {
+			mu.Lock()
+			arrivals = append(arrivals, time.Now())
+			mu.Unlock()
+
+			// Each request is slower than the window, so the waves overlap.
+			time.Sleep(3 * window)
+		}
+
+		return false
+	}
+
+	client := newTestClient(t, f, hn.WithBatchWindow(window, size), hn.WithMaxWorkers(0))
+
+	start := time.Now()
+
+	items, err := client.Items.List(context.Background(), idRange(1, 6), nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(items) != 6 {
+		t.Fatalf("got %d items, want 6", len(items))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// The requests of each wave arrive together, a window after the previous wave.
+	tests := []struct {
+		name     string
+		requests []time.Time
+		earliest time.Duration
+	}{
+		{"first wave", arrivals[0:2], window},
+		{"second wave", arrivals[2:4], 2 * window},
+		{"third wave", arrivals[4:6], 3 * window},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, at := range tt.requests {
+				if d := at.Sub(start); d < tt.earliest || d > tt.earliest+window {
+					t.Errorf("request sent after %v, want between %v and %v", d, tt.earliest, tt.earliest+window)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package hn
+
+import (
+	"slices"
+	"time"
+)
+
+// Bucket is a time interval of a fixed width with the number of items created in it.
+type Bucket struct {
+	Start time.Time
+	Count int
+}
+
+// BucketByTime counts the items by the time of their creation (e.g., the submissions per hour),
+// truncating the time of each item to a multiple of d since the zero time, like time.Time.Truncate,
+// so the daily buckets start at midnight UTC. The buckets are sorted by start time in ascending order,
+// and the intervals without any items are omitted. The items with the zero time are excluded.
+// If d is 0 or less, the items are bucketed by their exact time.
+func BucketByTime(items []Item, d time.Duration) []Bucket {
+	counts := make(map[time.Time]int)
+
+	for _, item := range items {
+		if item.Time.IsZero() {
+			continue
+		}
+
+		counts[item.Time.UTC().Truncate(d)]++
+	}
+
+	buckets := make([]Bucket, 0, len(counts))
+
+	for start, count := range counts {
+		buckets = append(buckets, Bucket{Start: start, Count: count})
+	}
+
+	slices.SortFunc(buckets, func(a, b Bucket) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return buckets
+}
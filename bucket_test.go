@@ -0,0 +1,76 @@
+package hn_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestBucketByTime(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	at := func(id uint, t time.Time) hn.Item {
+		item := newStory(id, 1)
+		item.Time = hn.Timestamp{Time: t}
+
+		return item
+	}
+
+	items := []hn.Item{
+		at(1, day.Add(10*time.Hour+5*time.Minute)),
+		at(2, day.Add(8*time.Hour+59*time.Minute)),
+		at(3, day.Add(10*time.Hour+55*time.Minute)),
+		at(4, day.Add(26*time.Hour)),
+		newStory(5, 1),
+		// The time zone of an item doesn't change its bucket.
+		at(6, day.Add(10*time.Hour+30*time.Minute).In(time.FixedZone("UTC+5", 5*60*60))),
+	}
+
+	tests := []struct {
+		name  string
+		items []hn.Item
+		d     time.Duration
+		want  []hn.Bucket
+	}{
+		{
+			"hourly",
+			items,
+			time.Hour,
+			[]hn.Bucket{{day.Add(8 * time.Hour), 1}, {day.Add(10 * time.Hour), 3}, {day.Add(26 * time.Hour), 1}},
+		},
+		{
+			"daily",
+			items,
+			24 * time.Hour,
+			[]hn.Bucket{{day, 4}, {day.Add(24 * time.Hour), 1}},
+		},
+		{
+			"exact time",
+			items[:2],
+			0,
+			[]hn.Bucket{{day.Add(8*time.Hour + 59*time.Minute), 1}, {day.Add(10*time.Hour + 5*time.Minute), 1}},
+		},
+		{"zero times only", []hn.Item{newStory(1, 1)}, time.Hour, []hn.Bucket{}},
+		{"empty", nil, time.Hour, []hn.Bucket{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hn.BucketByTime(tt.items, tt.d)
+
+			if !slices.EqualFunc(got, tt.want, func(a, b hn.Bucket) bool {
+				return a.Start.Equal(b.Start) && a.Count == b.Count
+			}) {
+				t.Errorf("BucketByTime() = %v, want %v", got, tt.want)
+			}
+
+			for _, b := range got {
+				if b.Start.Location() != time.UTC {
+					t.Errorf("bucket start %v, want UTC", b.Start)
+				}
+			}
+		})
+	}
+}
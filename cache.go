@@ -0,0 +1,184 @@
+package hn
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// itemCache is a concurrency-safe LRU cache of items keyed by ID.
+// All methods are safe to call on a nil cache, which never contains any items.
+type itemCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[uint]*list.Element
+}
+
+type cacheEntry struct {
+	item    Item
+	expires time.Time
+}
+
+func newItemCache(size int, ttl time.Duration) *itemCache {
+	return &itemCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[uint]*list.Element, size),
+	}
+}
+
+// get returns the cached item with the specified ID, if it exists and hasn't expired.
+func (c *itemCache) get(id uint) (Item, bool) {
+	if c == nil {
+		return Item{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return Item{}, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+
+	if c.ttl > 0 && time.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, id)
+		return Item{}, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.item, true
+}
+
+// add stores the item in the cache, evicting the least recently used item if the cache is full.
+func (c *itemCache) add(item Item) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{item: item, expires: time.Now().Add(c.ttl)}
+
+	if elem, ok := c.entries[item.ID]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[item.ID] = c.order.PushFront(entry)
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).item.ID)
+	}
+}
+
+// remove deletes the item with the specified ID from the cache.
+func (c *itemCache) remove(id uint) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[id]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, id)
+	}
+}
+
+// conditionalCacheSize is the maximum number of responses stored by the cache of WithConditionalCache.
+const conditionalCacheSize = 1000
+
+// conditionalCache is a concurrency-safe LRU cache of the responses with an ETag or Last-Modified header,
+// keyed by URL, used to send conditional requests. All methods are safe to call on a nil cache.
+type conditionalCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type conditionalEntry struct {
+	url          string
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+func newConditionalCache(size int) *conditionalCache {
+	return &conditionalCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached response for the request, if it exists.
+func (c *conditionalCache) get(req *http.Request) (conditionalEntry, bool) {
+	if c == nil || req.Method != http.MethodGet {
+		return conditionalEntry{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[req.URL.String()]
+	if !ok {
+		return conditionalEntry{}, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return *elem.Value.(*conditionalEntry), true
+}
+
+// cacheable reports whether the response to the request can be stored in the cache.
+func (c *conditionalCache) cacheable(req *http.Request, resp *http.Response) bool {
+	return c != nil && req.Method == http.MethodGet &&
+		(resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "")
+}
+
+// add stores the body of the response to the request in the cache,
+// evicting the least recently used response if the cache is full.
+func (c *conditionalCache) add(req *http.Request, resp *http.Response, body []byte) {
+	if !c.cacheable(req, resp) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &conditionalEntry{
+		url:          req.URL.String(),
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		body:         body,
+	}
+
+	if elem, ok := c.entries[entry.url]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[entry.url] = c.order.PushFront(entry)
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*conditionalEntry).url)
+	}
+}
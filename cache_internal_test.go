@@ -0,0 +1,52 @@
+package hn
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConditionalCacheEviction(t *testing.T) {
+	cache := newConditionalCache(2)
+
+	resp := &http.Response{Header: http.Header{"Etag": {`"v1"`}}}
+	request := func(path string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+	}
+
+	cache.add(request("/1"), resp, []byte("1"))
+	cache.add(request("/2"), resp, []byte("2"))
+
+	// Using the first response makes the second one the least recently used.
+	if _, ok := cache.get(request("/1")); !ok {
+		t.Fatal("get(/1) = false, want true")
+	}
+
+	cache.add(request("/3"), resp, []byte("3"))
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/1", true},
+		{"/2", false},
+		{"/3", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			entry, ok := cache.get(request(tt.path))
+			if ok != tt.want {
+				t.Fatalf("get(%s) = %v, want %v", tt.path, ok, tt.want)
+			}
+
+			if ok && string(entry.body) != tt.path[1:] {
+				t.Errorf("body = %q, want %q", entry.body, tt.path[1:])
+			}
+		})
+	}
+
+	if n := cache.order.Len(); n != 2 {
+		t.Errorf("cache holds %d responses, want 2", n)
+	}
+}
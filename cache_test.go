@@ -0,0 +1,132 @@
+package hn_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestWithItemCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		ttl      time.Duration
+		between  func(client *hn.Client)
+		requests int
+	}{
+		{"cached", 2, 0, func(*hn.Client) {}, 1},
+		{"cached within ttl", 2, time.Minute, func(*hn.Client) {}, 1},
+		{"evicted", 1, 0, func(client *hn.Client) {
+			_, _ = client.Items.Get(context.Background(), 2)
+		}, 2},
+		{"recently used", 2, 0, func(client *hn.Client) {
+			_, _ = client.Items.Get(context.Background(), 2)
+			_, _ = client.Items.Get(context.Background(), 1)
+			_, _ = client.Items.Get(context.Background(), 3)
+		}, 1},
+		{"expired", 2, time.Millisecond, func(*hn.Client) {
+			time.Sleep(5 * time.Millisecond)
+		}, 2},
+		{"invalidated", 2, 0, func(client *hn.Client) {
+			client.Items.Invalidate(1)
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{items: itemsOf(newStory(1, 10), newStory(2, 20), newStory(3, 30))}
+			client := newTestClient(t, f, hn.WithItemCache(tt.size, tt.ttl))
+
+			for i := range 2 {
+				item, err := client.Items.Get(context.Background(), 1)
+				if err != nil || item.ID != 1 {
+					t.Fatalf("Get() = %d, %v, want item 1", item.ID, err)
+				}
+
+				if i == 0 {
+					tt.between(client)
+				}
+			}
+
+			if n := f.count("/item/1.json"); n != tt.requests {
+				t.Errorf("got %d requests for item 1, want %d", n, tt.requests)
+			}
+		})
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []hn.Option
+		ids      []uint
+		requests int // requests for the items 1 to 3 sent by Get after Prefetch
+		wantErr  uint
+	}{
+		{"cache", []hn.Option{hn.WithItemCache(10, 0)}, []uint{1, 2, 3}, 0, 0},
+		{"missing item", []hn.Option{hn.WithItemCache(10, 0)}, []uint{1, 2, 3, 4}, 0, 0},
+		{"some items", []hn.Option{hn.WithItemCache(10, 0)}, []uint{1, 3}, 1, 0},
+		{"no cache", nil, []uint{1, 2, 3}, 3, 0},
+		{"failed item", []hn.Option{hn.WithItemCache(10, 0)}, []uint{1, 5}, 0, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{
+				items: itemsOf(newStory(1, 10), newStory(2, 20), newStory(3, 30)),
+				handler: func(w http.ResponseWriter, r *http.Request) bool {
+					if r.URL.Path != "/item/5.json" {
+						return false
+					}
+
+					http.Error(w, "bad request", http.StatusBadRequest)
+					return true
+				},
+			}
+			client := newTestClient(t, f, tt.opts...)
+			ctx := context.Background()
+
+			err := client.Items.Prefetch(ctx, tt.ids)
+
+			var itemErr *hn.ItemError
+			if tt.wantErr != 0 {
+				if !errors.As(err, &itemErr) || itemErr.ID != tt.wantErr {
+					t.Fatalf("Prefetch() error = %v, want an error of item %d", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Prefetch() error = %v", err)
+			}
+
+			before := f.total()
+
+			for id := uint(1); id <= 3; id++ {
+				if _, err := client.Items.Get(ctx, id); err != nil {
+					t.Fatalf("Get(%d) error = %v", id, err)
+				}
+			}
+
+			if n := f.total() - before; n != tt.requests {
+				t.Errorf("Get() sent %d requests after Prefetch, want %d", n, tt.requests)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		client := newTestClient(t, &fixture{items: itemsOf(newStory(1, 10))}, hn.WithItemCache(10, 0))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := client.Items.Prefetch(ctx, []uint{1}); !errors.Is(err, context.Canceled) {
+			t.Errorf("Prefetch() error = %v, want context.Canceled", err)
+		}
+	})
+}
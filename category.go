@@ -0,0 +1,124 @@
+package hn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Category is a list of stories provided by the API, identified by the name of its endpoint
+// (e.g., "topstories"). Other endpoints returning lists of IDs can be used as categories too.
+type Category string
+
+const (
+	CategoryTop  Category = "topstories"
+	CategoryNew  Category = "newstories"
+	CategoryBest Category = "beststories"
+	CategoryAsk  Category = "askstories"
+	CategoryShow Category = "showstories"
+	CategoryJob  Category = "jobstories"
+)
+
+// Categories lists all the categories of stories provided by the API.
+var Categories = []Category{CategoryTop, CategoryNew, CategoryBest, CategoryAsk, CategoryShow, CategoryJob}
+
+// Stories returns a list of IDs for the stories of the category.
+func (s *LiveService) Stories(ctx context.Context, category Category) ([]uint, error) {
+	return fetch[ids](ctx, s.client, s.opts, http.MethodGet, "/"+string(category))
+}
+
+// StoriesList returns a list of items for the stories of the category, filtered if necessary.
+func (s *LiveService) StoriesList(ctx context.Context, category Category, filter func(Item) bool) ([]Item, error) {
+	ids, err := s.Stories(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.items.List(ctx, ids, filter)
+}
+
+// storiesPage returns a page of items for the stories of the category, filtered if necessary.
+// Only the items at positions [offset, offset+limit) of the list are fetched, and a limit of 0 means no limit.
+func (s *LiveService) storiesPage(ctx context.Context, category Category, offset, limit uint, filter func(Item) bool) ([]Item, error) {
+	ids, err := s.Stories(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.items.List(ctx, page(ids, offset, limit), filter)
+}
+
+// RankedItem is an item of a list of stories along with its rank (e.g., the position on the front page).
+type RankedItem struct {
+	Rank     int // 1-based position among the returned items
+	Position int // 1-based position in the original list, counting the items that were filtered out (0 if unknown)
+	Item     Item
+}
+
+// StoriesListRanked is like StoriesList, but returns the items with their ranks in the list.
+// The ranks are assigned after filtering, so they have no gaps, and the positions in the original list
+// are kept in the Position field.
+func (s *LiveService) StoriesListRanked(ctx context.Context, category Category, filter func(Item) bool) ([]RankedItem, error) {
+	ids, err := s.Stories(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.items.List(ctx, ids, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]RankedItem, len(items))
+
+	// The items are in the order of ids, so the position of each item is found by moving forward in ids.
+	pos := 0
+
+	for i, item := range items {
+		ranked[i] = RankedItem{Rank: i + 1, Item: item}
+
+		if j := slices.Index(ids[pos:], item.ID); j >= 0 {
+			pos += j + 1
+			ranked[i].Position = pos
+		}
+	}
+
+	return ranked, nil
+}
+
+// TopListRanked returns a list of items for the top stories, filtered if necessary, with their ranks.
+// See StoriesListRanked for the details.
+func (s *LiveService) TopListRanked(ctx context.Context, filter func(Item) bool) ([]RankedItem, error) {
+	return s.StoriesListRanked(ctx, CategoryTop, filter)
+}
+
+// Merge returns a list of items for the stories of all the given categories (e.g., the top and the best stories),
+// filtered if necessary. The lists of IDs are fetched concurrently, and each item is fetched and returned once,
+// in the order of its first appearance in the categories.
+func (s *LiveService) Merge(ctx context.Context, categories []Category, filter func(Item) bool) ([]Item, error) {
+	lists := make([][]uint, len(categories))
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	for i, category := range categories {
+		g.Go(func() error {
+			ids, err := s.Stories(gctx, category)
+			if err != nil {
+				return fmt.Errorf("category %s: %w", category, err)
+			}
+
+			lists[i] = ids
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return s.items.List(ctx, diff(slices.Concat(lists...), nil), filter)
+}
@@ -0,0 +1,204 @@
+package hn_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+// categories returns a fixture where each category lists three of its own items: the top stories are 1 to 3,
+// the new stories are 11 to 13, and so on, in the order of Categories.
+func categories() *fixture {
+	f := &fixture{items: make(map[uint]hn.Item), lists: make(map[string][]uint)}
+
+	types := map[hn.Category]string{hn.CategoryAsk: hn.AskType, hn.CategoryJob: hn.JobType}
+
+	for i, category := range hn.Categories {
+		for id := uint(i*10 + 1); id <= uint(i*10+3); id++ {
+			item := newStory(id, int(id))
+			if t, ok := types[category]; ok {
+				item.Type = t
+			}
+
+			f.items[id] = item
+			f.lists[string(category)] = append(f.lists[string(category)], id)
+		}
+	}
+
+	return f
+}
+
+func TestListPages(t *testing.T) {
+	client := newTestClient(t, categories())
+	live := client.Live
+	ctx := context.Background()
+
+	ids := func(items []hn.Item, err error) ([]uint, error) { return hn.IDsOf(items), err }
+
+	tests := []struct {
+		name string
+		page func(offset, limit uint) ([]uint, error)
+		all  []uint
+	}{
+		{"Top", func(o, l uint) ([]uint, error) { return ids(live.TopListPage(ctx, o, l, nil)) }, []uint{1, 2, 3}},
+		{"New", func(o, l uint) ([]uint, error) { return ids(live.NewListPage(ctx, o, l, nil)) }, []uint{11, 12, 13}},
+		{"Best", func(o, l uint) ([]uint, error) { return ids(live.BestListPage(ctx, o, l, nil)) }, []uint{21, 22, 23}},
+		{"Ask", func(o, l uint) ([]uint, error) {
+			asks, err := live.AskListPage(ctx, o, l, nil)
+			return hn.IDsOf(asks), err
+		}, []uint{31, 32, 33}},
+		{"Show", func(o, l uint) ([]uint, error) {
+			shows, err := live.ShowListPage(ctx, o, l, nil)
+			return hn.IDsOf(shows), err
+		}, []uint{41, 42, 43}},
+		{"Job", func(o, l uint) ([]uint, error) {
+			jobs, err := live.JobListPage(ctx, o, l, nil)
+			return hn.IDsOf(jobs), err
+		}, []uint{51, 52, 53}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := []struct {
+				offset, limit uint
+				want          []uint
+			}{
+				{0, 0, tt.all},
+				{1, 1, tt.all[1:2]},
+				{1, 0, tt.all[1:]},
+				{2, 5, tt.all[2:]},
+				{5, 1, []uint{}},
+			}
+
+			for _, p := range pages {
+				got, err := tt.page(p.offset, p.limit)
+				if err != nil {
+					t.Fatalf("page(%d, %d) error = %v", p.offset, p.limit, err)
+				}
+
+				if !slices.Equal(got, p.want) {
+					t.Errorf("page(%d, %d) = %v, want %v", p.offset, p.limit, got, p.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTypedLists(t *testing.T) {
+	client := newTestClient(t, categories())
+	ctx := context.Background()
+
+	asks, err := client.Live.AskList(ctx, nil)
+	if err != nil || !slices.Equal(hn.IDsOf(asks), []uint{31, 32, 33}) {
+		t.Errorf("AskList() = %v, %v, want asks 31 to 33", hn.IDsOf(asks), err)
+	}
+
+	shows, err := client.Live.ShowList(ctx, func(item hn.Item) bool { return item.Score > 41 })
+	if err != nil || !slices.Equal(hn.IDsOf(shows), []uint{42, 43}) {
+		t.Errorf("ShowList() = %v, %v, want shows 42 and 43", hn.IDsOf(shows), err)
+	}
+
+	jobs, err := client.Live.JobList(ctx, nil)
+	if err != nil || !slices.Equal(hn.IDsOf(jobs), []uint{51, 52, 53}) {
+		t.Errorf("JobList() = %v, %v, want jobs 51 to 53", hn.IDsOf(jobs), err)
+	}
+}
+
+func TestTopListRanked(t *testing.T) {
+	// The story 6 is in the list, but it's missing.
+	f := &fixture{
+		items: itemsOf(newStory(1, 10), newComment(2, 1), newStory(3, 30), newStory(4, 5), newStory(5, 50)),
+		lists: map[string][]uint{"topstories": {5, 2, 6, 3, 1, 4}},
+	}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name   string
+		filter func(hn.Item) bool
+		want   []hn.RankedItem
+	}{
+		{"no filter", nil, []hn.RankedItem{{Rank: 1, Position: 1}, {Rank: 2, Position: 2}, {Rank: 3, Position: 4}, {Rank: 4, Position: 5}, {Rank: 5, Position: 6}}},
+		{"stories", hn.ByType(hn.StoryType), []hn.RankedItem{{Rank: 1, Position: 1}, {Rank: 2, Position: 4}, {Rank: 3, Position: 5}, {Rank: 4, Position: 6}}},
+		{"high score", hn.MinScore(10), []hn.RankedItem{{Rank: 1, Position: 1}, {Rank: 2, Position: 4}, {Rank: 3, Position: 5}}},
+		{"nothing", hn.MinScore(100), []hn.RankedItem{}},
+	}
+
+	ids := map[int]uint{1: 5, 2: 2, 4: 3, 5: 1, 6: 4}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked, err := client.Live.TopListRanked(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("TopListRanked() error = %v", err)
+			}
+
+			if len(ranked) != len(tt.want) {
+				t.Fatalf("TopListRanked() returned %d items, want %d", len(ranked), len(tt.want))
+			}
+
+			for i, r := range ranked {
+				want := tt.want[i]
+				if r.Rank != want.Rank || r.Position != want.Position || r.Item.ID != ids[want.Position] {
+					t.Errorf("item %d = rank %d, position %d, ID %d, want rank %d, position %d, ID %d",
+						i, r.Rank, r.Position, r.Item.ID, want.Rank, want.Position, ids[want.Position])
+				}
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	f := &fixture{
+		items: itemsOf(newStory(1, 10), newStory(2, 20), newStory(3, 30), newStory(4, 40), newItem(5, hn.JobType)),
+		lists: map[string][]uint{
+			"topstories":  {3, 1, 2},
+			"beststories": {2, 4, 3},
+			"newstories":  {5, 4, 1},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		categories []hn.Category
+		filter     func(hn.Item) bool
+		want       []uint
+	}{
+		{"single category", []hn.Category{hn.CategoryTop}, nil, []uint{3, 1, 2}},
+		{"overlapping categories", []hn.Category{hn.CategoryTop, hn.CategoryBest}, nil, []uint{3, 1, 2, 4}},
+		{"all categories", []hn.Category{hn.CategoryNew, hn.CategoryTop, hn.CategoryBest}, nil, []uint{5, 4, 1, 3, 2}},
+		{"filtered", []hn.Category{hn.CategoryNew, hn.CategoryTop}, hn.ByType(hn.StoryType), []uint{4, 1, 3, 2}},
+		{"same category twice", []hn.Category{hn.CategoryBest, hn.CategoryBest}, nil, []uint{2, 4, 3}},
+		{"no categories", nil, nil, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{items: f.items, lists: f.lists}
+			client := newTestClient(t, f)
+
+			items, err := client.Live.Merge(context.Background(), tt.categories, tt.filter)
+			if err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+
+			if got := hn.IDsOf(items); !slices.Equal(got, tt.want) {
+				t.Errorf("Merge() = %v, want %v", got, tt.want)
+			}
+
+			for id := range f.items {
+				if n := f.count("/item/" + strconv.Itoa(int(id)) + ".json"); n > 1 {
+					t.Errorf("item %d fetched %d times, want once", id, n)
+				}
+			}
+		})
+	}
+
+	client := newTestClient(t, f)
+	if _, err := client.Live.Merge(context.Background(), []hn.Category{hn.CategoryTop, hn.CategoryAsk}, nil); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("Merge() with a missing category error = %v, want ErrNotFound", err)
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"html"
-	"io"
+	"iter"
 	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -17,6 +20,9 @@ import (
 const (
 	version = "0.0.1"
 
+	// defaultMaxWorkers is the default worker limit and the connection limit of the default client.
+	defaultMaxWorkers = 100
+
 	baseURL   = "https://hacker-news.firebaseio.com/v0"
 	userAgent = "hn-client" + "/" + version
 
@@ -30,55 +36,164 @@ const (
 
 var (
 	ErrNotFound = errors.New("item is not found")
+	ErrCycle    = errors.New("cycle in parent links")
+
+	ErrUnknownType           = errors.New("unknown item type")
+	ErrResponseTooLarge      = errors.New("response body is too large")
+	ErrUnexpectedContentType = errors.New("unexpected content type")
+
+	// maxWorkers is the maximum number of concurrent requests of multiple item fetch operations.
+	// By default, it matches the connection limit of the default client, so large lists
+	// don't start hundreds of requests waiting for a connection at once.
+	maxWorkers = defaultMaxWorkers
+
+	// streamWindow is the number of items fetched ahead of the consumer
+	// by ordered streams when there is no limit to the number of workers.
+	streamWindow = 100
+
+	// maxRecentSince is the maximum number of items fetched by LiveService.RecentSince in a single call.
+	maxRecentSince uint = 1000
+
+	// maxUserTopStories is the number of the most recent submissions checked by UserService.TopStories.
+	maxUserTopStories = 1000
+
+	// defaultTimeout is the timeout of the requests sent by the default client.
+	defaultTimeout = 30 * time.Second
 
-	maxWorkers = -1
+	// defaultMaxResponseBytes is the default limit of the size of a response body (see WithMaxResponseBytes).
+	defaultMaxResponseBytes int64 = 16 << 20
 
 	defaultClient = &http.Client{
+		Timeout: defaultTimeout,
 		Transport: &http.Transport{
 			MaxIdleConns:    100,
-			MaxConnsPerHost: 100,
+			MaxConnsPerHost: defaultMaxWorkers,
 			IdleConnTimeout: 90 * time.Second,
 		},
 	}
 )
 
 // SetMaxWorkers sets the maximum number of workers for multiple item fetch operations.
-// The default value of maxWorkers is -1, meaning there is no limit to the number of workers.
+// The default value is 100, and a value of 0 or less means there is no limit to the number of workers.
 func SetMaxWorkers(n int) {
 	maxWorkers = n
 }
 
+// DefaultTransport returns a copy of the transport used by the default client,
+// so it can be customized or wrapped (e.g., for tracing) without losing its connection pool settings.
+func DefaultTransport() *http.Transport {
+	return defaultClient.Transport.(*http.Transport).Clone()
+}
+
 // Client represents a client for the Hacker News API.
 type Client struct {
-	Items *ItemService
-	Users *UserService
-	Live  *LiveService
+	Items  *ItemService
+	Users  *UserService
+	Live   *LiveService
+	Search *SearchService
+
+	// httpClient and opts are the arguments of NewClient, kept for Clone.
+	httpClient *http.Client
+	opts       []Option
 }
 
-// NewClient returns a new Hacker News API client. If httpClient is nil, the default client will be used.
-func NewClient(httpClient *http.Client) *Client {
+// NewClient returns a new Hacker News API client. If httpClient is nil, the default client will be used,
+// which has a request timeout of 30 seconds.
+// The behavior of the client can be changed with opts.
+func NewClient(httpClient *http.Client, opts ...Option) *Client {
 	httpClient = cmp.Or(httpClient, defaultClient)
 
 	var (
-		items = &ItemService{client: httpClient}
-		users = &UserService{client: httpClient, items: items}
-		live  = &LiveService{client: httpClient, items: items}
+		base = httpClient
+		o    = newOptions(opts...)
+	)
+
+	if o.pool != nil {
+		if o.transport != nil {
+			o.transport = o.pool.transport(o.transport)
+		} else {
+			o.transport = o.pool.transport(httpClient.Transport)
+		}
+	}
+
+	if o.timeout != nil || o.transport != nil {
+		// Copy the client, so the client passed by the caller is not changed.
+		c := *httpClient
+
+		if o.timeout != nil {
+			c.Timeout = *o.timeout
+		}
+
+		if o.transport != nil {
+			c.Transport = o.transport
+		}
+
+		httpClient = &c
+	}
+
+	var cache *itemCache
+	if o.cacheSize > 0 {
+		cache = newItemCache(o.cacheSize, o.cacheTTL)
+	}
+
+	var batch *batcher
+	if o.batchSize > 0 {
+		batch = newBatcher(o.batchWindow, o.batchSize)
+	}
+
+	var pool *workerPool
+	if o.poolSize > 0 {
+		pool = newWorkerPool(o.poolSize)
+	}
+
+	var (
+		items  = &ItemService{client: httpClient, opts: o, cache: cache, batch: batch, pool: pool}
+		users  = &UserService{client: httpClient, opts: o, items: items}
+		live   = &LiveService{client: httpClient, opts: o, items: items}
+		search = &SearchService{client: httpClient, opts: o}
 	)
 
 	return &Client{
-		Items: items,
-		Users: users,
-		Live:  live,
+		Items:  items,
+		Users:  users,
+		Live:   live,
+		Search: search,
+
+		httpClient: base,
+		opts:       slices.Clone(opts),
 	}
 }
 
+// Clone returns a new client with the configuration of c (the HTTP client and the options passed to NewClient),
+// with opts applied on top of it. The clone has its own services, caches and limits, so c is not affected.
+func (c *Client) Clone(opts ...Option) *Client {
+	return NewClient(c.httpClient, slices.Concat(c.opts, opts)...)
+}
+
 // baseItem is a base type for all items, containing only the fields common to all items.
+// Deleted and Dead are set for the items that were removed or flagged on Hacker News.
 type baseItem struct {
-	ID    uint      `json:"id,omitempty"`
-	By    string    `json:"by,omitempty"`
-	Score int       `json:"score,omitempty"`
-	Time  Timestamp `json:"time,omitzero"`
-	Type  string    `json:"type,omitempty"`
+	ID      uint      `json:"id,omitempty"`
+	By      string    `json:"by,omitempty"`
+	Score   int       `json:"score,omitempty"`
+	Time    Timestamp `json:"time,omitzero"`
+	Type    string    `json:"type,omitempty"`
+	Deleted bool      `json:"deleted,omitempty"`
+	Dead    bool      `json:"dead,omitempty"`
+
+	// rawText is the text of the item as it's stored on Hacker News (HTML with escaped entities),
+	// kept when the text is unescaped, so the HTML can still be rendered (e.g., by Comment.Plain).
+	rawText string
+}
+
+// markup returns the HTML of the text of the item: the raw text kept when the text was unescaped,
+// or text itself if it was never unescaped (e.g., with WithRawText).
+func (i baseItem) markup(text string) string {
+	if i.rawText != "" {
+		return i.rawText
+	}
+
+	return text
 }
 
 func (i baseItem) getID() uint {
@@ -101,9 +216,22 @@ func (i baseItem) getType() string {
 	return i.Type
 }
 
+// getDescendants returns 0 for the types without descendants (e.g., Comment or PollOption).
+// The types with descendants override it with their own value.
+func (i baseItem) getDescendants() int {
+	return 0
+}
+
+// getReplies returns 0 for the types without kids (e.g., Job or PollOption).
+// The types with kids override it with the number of their kids.
+func (i baseItem) getReplies() int {
+	return 0
+}
+
 // Item is a common type for all other types: story, comment, poll, etc.
 // It contains all fields, so some of them may be empty if the value of the
-// specific type doesn't have that field.
+// specific type doesn't have that field. The fields that are null in the JSON
+// of an item (e.g., "score": null) are decoded as zero values, the same as the missing fields.
 type Item struct {
 	baseItem
 
@@ -117,6 +245,29 @@ type Item struct {
 	URL         string `json:"url,omitempty"`
 }
 
+// Unescaped returns a copy of the item with HTML entities (e.g., "&#x27;" or "&gt;")
+// decoded in all text fields. Items returned by ItemService are already unescaped
+// unless the client was created with WithRawText. The original HTML of the text is kept,
+// so the methods rendering it (e.g., Comment.Plain) give the same result for both kinds of items.
+func (i Item) Unescaped() Item {
+	if i.rawText == "" {
+		i.rawText = i.Text
+	}
+
+	i.Text = html.UnescapeString(i.Text)
+	i.Title = html.UnescapeString(i.Title)
+
+	return i
+}
+
+func (i Item) getDescendants() int {
+	return i.Descendants
+}
+
+func (i Item) getReplies() int {
+	return len(i.Kids)
+}
+
 type Story struct {
 	baseItem
 
@@ -131,6 +282,14 @@ func (s Story) Type() string {
 	return StoryType
 }
 
+func (s Story) getDescendants() int {
+	return s.Descendants
+}
+
+func (s Story) getReplies() int {
+	return len(s.Kids)
+}
+
 type Comment struct {
 	baseItem
 
@@ -143,6 +302,10 @@ func (c Comment) Type() string {
 	return CommentType
 }
 
+func (c Comment) getReplies() int {
+	return len(c.Kids)
+}
+
 type Ask struct {
 	baseItem
 
@@ -156,6 +319,14 @@ func (a Ask) Type() string {
 	return AskType
 }
 
+func (a Ask) getDescendants() int {
+	return a.Descendants
+}
+
+func (a Ask) getReplies() int {
+	return len(a.Kids)
+}
+
 type Job struct {
 	baseItem
 
@@ -182,6 +353,14 @@ func (p Poll) Type() string {
 	return PollType
 }
 
+func (p Poll) getDescendants() int {
+	return p.Descendants
+}
+
+func (p Poll) getReplies() int {
+	return len(p.Kids)
+}
+
 type PollOption struct {
 	baseItem
 
@@ -201,11 +380,87 @@ type User struct {
 	Submitted []uint    `json:"submitted,omitempty"`
 }
 
+// Unescaped returns a copy of the user with HTML entities decoded in the About field.
+func (u User) Unescaped() User {
+	u.About = html.UnescapeString(u.About)
+
+	return u
+}
+
+// UserSummary contains the summary of a user profile.
+type UserSummary struct {
+	Karma           int
+	AccountAge      time.Duration
+	SubmissionCount int
+	KarmaPerDay     float64 // average karma per day since the account was created
+}
+
+// Summary returns the summary of the user profile at the given time.
+// An account younger than a day is treated as one day old for KarmaPerDay.
+func (u User) Summary(now time.Time) UserSummary {
+	age := now.Sub(u.Created.Time)
+	days := max(age.Hours()/24, 1)
+
+	return UserSummary{
+		Karma:           u.Karma,
+		AccountAge:      age,
+		SubmissionCount: len(u.Submitted),
+		KarmaPerDay:     float64(u.Karma) / days,
+	}
+}
+
+// Update contains the IDs of the recently changed items and profiles.
+// Skipped is the number of malformed entries (e.g., a profile name that is not a string)
+// that were skipped when the update was decoded.
 type Update struct {
 	Items    []uint   `json:"items,omitempty"`
 	Profiles []string `json:"profiles,omitempty"`
+	Skipped  int      `json:"-"`
+}
+
+// UnmarshalJSON decodes an update, skipping the malformed entries instead of failing,
+// so a single bad entry doesn't lose the whole update. The item IDs are decoded like the IDs of items.
+func (u *Update) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Items    []json.RawMessage `json:"items"`
+		Profiles []json.RawMessage `json:"profiles"`
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	*u = Update{}
+
+	for _, raw := range aux.Items {
+		var v id
+
+		if err := json.Unmarshal(raw, &v); err != nil || string(raw) == "null" {
+			u.Skipped++
+			continue
+		}
+
+		u.Items = append(u.Items, uint(v))
+	}
+
+	for _, raw := range aux.Profiles {
+		var profile string
+
+		if err := json.Unmarshal(raw, &profile); err != nil || string(raw) == "null" {
+			u.Skipped++
+			continue
+		}
+
+		u.Profiles = append(u.Profiles, profile)
+	}
+
+	return nil
 }
 
+// Timestamp is a time encoded in JSON as Unix time in seconds.
+// The zero time is encoded as 0, and 0 is decoded as the zero time.
+// Decoded times are always in UTC, regardless of the local time zone.
 type Timestamp struct {
 	time.Time
 }
@@ -218,128 +473,603 @@ func (t *Timestamp) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	t.Time = time.Unix(timestamp, 0)
+	if timestamp == 0 {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	t.Time = time.Unix(timestamp, 0).UTC()
 
 	return nil
 }
 
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+
+	return json.Marshal(t.Unix())
+}
+
 // ItemService provides methods to retrieve data about Hacker News items.
 type ItemService struct {
 	client *http.Client
+	opts   *options
+	cache  *itemCache
+	batch  *batcher
+	pool   *workerPool
+	group  flightGroup
 }
 
 // Get return an Item with the specified ID.
+// If the item cache is enabled, the cached item is returned without sending a request.
+//
+// Concurrent calls for the same ID share a single request. Canceling ctx makes Get return
+// ctx.Err() immediately, but the shared request is canceled only when all the callers waiting for it are canceled.
 func (s *ItemService) Get(ctx context.Context, id uint) (Item, error) {
-	item, err := Fetch[Item](ctx, s.client, http.MethodGet, fmt.Sprintf("/item/%d", id))
+	if item, ok := s.cache.get(id); ok {
+		return item, nil
+	}
+
+	if s.batch != nil {
+		return s.group.do(ctx, id, func(ctx context.Context, id uint) (Item, error) {
+			return s.batch.do(ctx, id, s.fetch)
+		})
+	}
+
+	return s.group.do(ctx, id, s.fetch)
+}
+
+// fetch sends a request for the item with the specified ID and stores the result in the item cache.
+func (s *ItemService) fetch(ctx context.Context, id uint) (Item, error) {
+	item, err := fetch[Item](ctx, s.client, s.opts, http.MethodGet, fmt.Sprintf("/item/%d", id))
 	if err != nil {
 		return Item{}, err
 	}
 
-	if item.Text != "" {
-		item.Text = html.UnescapeString(item.Text)
+	if err := s.checkType(item); err != nil {
+		return Item{}, err
 	}
 
-	if item.Title != "" {
-		item.Title = html.UnescapeString(item.Title)
+	if !s.opts.rawText {
+		item = item.Unescaped()
 	}
 
+	s.cache.add(item)
+
 	return item, nil
 }
 
+// checkType returns an error wrapping ErrUnknownType if the strict types are enabled
+// and the type of the item is not recognized.
+func (s *ItemService) checkType(item Item) error {
+	if s.opts.strictTypes && item.Kind() == KindUnknown {
+		return fmt.Errorf("item %d: %w: %q", item.ID, ErrUnknownType, item.Type)
+	}
+
+	return nil
+}
+
+// Invalidate removes the item with the specified ID from the item cache,
+// so the next Get fetches it from the API again.
+func (s *ItemService) Invalidate(id uint) {
+	s.cache.remove(id)
+}
+
+// Exists reports whether the item with the specified ID exists and is not deleted.
+// It returns false for the items that are not found (null) or deleted, and an error if the item can't be fetched.
+func (s *ItemService) Exists(ctx context.Context, id uint) (bool, error) {
+	item, err := s.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return !item.Deleted, nil
+}
+
+// Prefetch fetches the items with specific IDs concurrently, bounded by the worker limit, to populate
+// the item cache (see WithItemCache), so the next calls of Get for these IDs don't send any requests.
+// The items that are already cached or not found are skipped. If any other item can't be fetched,
+// it returns an *ItemError for that item. Without the item cache, Prefetch has no effect other than the requests.
+func (s *ItemService) Prefetch(ctx context.Context, ids []uint) error {
+	_, err := s.getEach(ctx, ids)
+	return err
+}
+
+// GetRaw returns an Item with the specified ID along with the JSON value returned by the API.
+// The raw JSON is exactly what the API returned (before HTML unescaping), so it can also contain
+// the fields that are not present in Item. GetRaw always sends a request, bypassing the item cache.
+func (s *ItemService) GetRaw(ctx context.Context, id uint) (Item, json.RawMessage, error) {
+	raw, err := fetch[json.RawMessage](ctx, s.client, s.opts, http.MethodGet, fmt.Sprintf("/item/%d", id))
+	if err != nil {
+		return Item{}, nil, err
+	}
+
+	var item Item
+
+	err = json.Unmarshal(raw, &item)
+	if err != nil {
+		return Item{}, nil, fmt.Errorf("decode response JSON: %w", err)
+	}
+
+	if err := s.checkType(item); err != nil {
+		return Item{}, nil, err
+	}
+
+	if !s.opts.rawText {
+		item = item.Unescaped()
+	}
+
+	return item, raw, nil
+}
+
+// GetAs returns an item with the specified ID, converted to a struct of a specific type
+// (Comment, Story, Ask, Job, Poll or PollOption). It returns ErrNotFound if the item doesn't exist,
+// or an error if the type of the item doesn't match the output type.
+func GetAs[C Convertible](ctx context.Context, s *ItemService, id uint) (C, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		var c C
+		return c, err
+	}
+
+	return To[C](item)
+}
+
+// keep reports whether the item matches the filter of a list operation
+// and the filters enabled by the client options.
+func (s *ItemService) keep(item Item, filter func(Item) bool) bool {
+	if s.opts.skipDeadDeleted && !FilterAlive(item) {
+		return false
+	}
+
+	if s.opts.defaultFilter != nil && !s.opts.defaultFilter(item) {
+		return false
+	}
+
+	return filter == nil || filter(item)
+}
+
+// progress returns a function that reports the completion of an item of a batch with the given size
+// to the progress callback. The callback calls are serialized, so the number of completed items always grows.
+func (s *ItemService) progress(total int) func() {
+	if s.opts.progress == nil {
+		return func() {}
+	}
+
+	var (
+		mu   sync.Mutex
+		done int
+	)
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		done++
+		s.opts.progress(done, total)
+	}
+}
+
 // List returns a list of items with specific IDs, filtered if necessary.
+// The items that are not found (e.g., the removed submissions of a user) are skipped.
+// If any other item can't be fetched, it returns an *ItemError for that item.
 func (s *ItemService) List(ctx context.Context, ids []uint, filter func(Item) bool) ([]Item, error) {
+	// List is usually the second stage of a composite method (e.g., LiveService.TopList),
+	// so it must not return the cached items if ctx was canceled after the first stage.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if len(ids) == 0 {
 		return []Item{}, nil
 	}
 
+	// Each worker writes its result at the index of its ID, so no synchronization is needed.
 	var (
-		processed = make(chan Item, 10)
-		wait      = make(chan struct{})
-		items     = make([]Item, 0, len(ids))
-		itemsMap  = make(map[uint]Item, len(ids))
+		fetched = make([]Item, len(ids))
+		matched = make([]bool, len(ids))
 	)
 
-	g, ctx := errgroup.WithContext(ctx)
-	g.SetLimit(maxWorkers)
+	done := s.progress(len(ids))
 
-	go func() {
-		defer close(wait)
+	err := s.forEach(ctx, len(ids), func(ctx context.Context, i int) error {
+		defer done()
 
-		for {
-			select {
-			case item, ok := <-processed:
-				if ok {
-					itemsMap[item.ID] = item
-				} else {
-					return
-				}
-			case <-ctx.Done():
-				return
-			}
+		item, err := s.Get(ctx, ids[i])
+		if errors.Is(err, ErrNotFound) {
+			return nil
 		}
-	}()
 
-	for _, id := range ids {
-		g.Go(func() error {
-			item, err := s.Get(ctx, id)
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			return &ItemError{ID: ids[i], Err: err}
+		}
 
-			if filter != nil && filter(item) {
-				processed <- item
-			} else if filter == nil {
-				processed <- item
-			}
+		fetched[i], matched[i] = item, s.keep(item, filter)
 
-			return nil
-		})
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	if err := g.Wait(); err != nil {
+	return compact(fetched, matched), nil
+}
+
+// ListAs returns a list of items with specific IDs, filtered if necessary and converted to structs
+// of a specific type (Comment, Story, Ask, Job, Poll or PollOption). Items of other types are excluded
+// before the filter is applied.
+func ListAs[C Convertible](ctx context.Context, s *ItemService, ids []uint, filter func(Item) bool) ([]C, error) {
+	var c C
+
+	items, err := s.List(ctx, ids, func(item Item) bool {
+		return item.Type == c.Type() && (filter == nil || filter(item))
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
-	close(processed)
+	return ToList[C](items), nil
+}
+
+// ListMap is like List, but returns the items keyed by their IDs.
+// The items that are filtered out are not present in the map.
+func (s *ItemService) ListMap(ctx context.Context, ids []uint, filter func(Item) bool) (map[uint]Item, error) {
+	items, err := s.List(ctx, ids, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[uint]Item, len(items))
+	for _, item := range items {
+		m[item.ID] = item
+	}
+
+	return m, nil
+}
+
+// ListPartial returns a list of items with specific IDs, filtered if necessary.
+//
+// Unlike List, it doesn't fail the entire batch if some of the items can't be fetched:
+// the items that were fetched successfully are returned in the order of ids,
+// and the errors are reported separately for each ID. The returned error slice is nil
+// if all items were fetched, otherwise it has the same length as ids and
+// the error at index i belongs to ids[i] (nil for the items fetched without errors).
+func (s *ItemService) ListPartial(ctx context.Context, ids []uint, filter func(Item) bool) ([]Item, []error) {
+	if len(ids) == 0 {
+		return []Item{}, nil
+	}
+
+	var (
+		fetched = make([]Item, len(ids))
+		matched = make([]bool, len(ids))
+		errs    = make([]error, len(ids))
+		failed  atomic.Bool
+	)
 
-	<-wait
+	done := s.progress(len(ids))
 
-	for _, id := range ids {
-		if item, ok := itemsMap[id]; ok {
-			items = append(items, item)
+	// The workers never return errors, so the other items are fetched even if some of them fail.
+	_ = s.forEach(ctx, len(ids), func(ctx context.Context, i int) error {
+		defer done()
+
+		item, err := s.Get(ctx, ids[i])
+		if err != nil {
+			errs[i] = &ItemError{ID: ids[i], Err: err}
+			failed.Store(true)
+			return nil
 		}
+
+		fetched[i], matched[i] = item, s.keep(item, filter)
+
+		return nil
+	})
+
+	items := compact(fetched, matched)
+
+	if !failed.Load() {
+		return items, nil
 	}
 
-	return items, nil
+	return items, errs
+}
+
+// compact returns the items that are marked as matched, preserving their order.
+func compact(items []Item, matched []bool) []Item {
+	list := make([]Item, 0, len(items))
+
+	for i, item := range items {
+		if matched[i] {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// Stream returns an iterator over the items with specific IDs, filtered if necessary.
+//
+// Each item (or an error) is yielded as soon as it has been fetched, so the order of
+// the items is not guaranteed to match the order of ids. Like List, it skips the items that are not found,
+// and yields an *ItemError for any other item that can't be fetched. The number of concurrent
+// fetches is bounded by the worker limit, or by the worker pool if it's enabled (see WithWorkerPool).
+// With the pool, the fetches don't wait for the consumer, so the items fetched ahead of it are buffered.
+// Stopping the iteration cancels all outstanding requests.
+func (s *ItemService) Stream(ctx context.Context, ids []uint, filter func(Item) bool) iter.Seq2[Item, error] {
+	type result struct {
+		item Item
+		err  error
+	}
+
+	return func(yield func(Item, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// The workers of the pool are shared with other calls, which could be made by the consumer
+		// while it holds a result (e.g., List of the kids of an item), so they must never wait for
+		// the consumer: the results are buffered, and the pool bounds the number of fetches instead.
+		var results chan result
+		if s.pool != nil {
+			results = make(chan result, len(ids))
+		} else {
+			results = make(chan result)
+		}
+
+		go func() {
+			defer close(results)
+
+			_ = s.forEach(ctx, len(ids), func(ctx context.Context, i int) error {
+				// The iteration has stopped, so the remaining IDs are not fetched.
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				item, err := s.Get(ctx, ids[i])
+				if errors.Is(err, ErrNotFound) {
+					return nil
+				}
+
+				if err != nil {
+					err = &ItemError{ID: ids[i], Err: err}
+				} else if !s.keep(item, filter) {
+					return nil
+				}
+
+				select {
+				case results <- result{item: item, err: err}:
+				case <-ctx.Done():
+				}
+
+				return nil
+			})
+		}()
+
+		for r := range results {
+			if !yield(r.item, r.err) {
+				break
+			}
+		}
+
+		cancel()
+
+		// Wait for all workers to stop before returning.
+		for range results {
+		}
+	}
+}
+
+// streamOrdered returns an iterator over the items with specific IDs, filtered if necessary,
+// in the same order as ids. The items that are not found are skipped, like in Stream.
+// At most the worker limit (or streamWindow, if there is no limit) of the items are fetched
+// ahead of the consumer, and the fetches run on the worker pool if it's enabled. Stopping the iteration cancels all outstanding requests.
+func (s *ItemService) streamOrdered(ctx context.Context, ids []uint, filter func(Item) bool) iter.Seq2[Item, error] {
+	type result struct {
+		item Item
+		err  error
+	}
+
+	return func(yield func(Item, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		window := s.opts.workerLimit()
+		if window <= 0 {
+			window = streamWindow
+		}
+
+		// The consumer holds the result channel of the item it's waiting for, so the buffer holds
+		// one less than the window: the fetch of the next item starts only when it fits in the buffer.
+		var (
+			pending = make(chan chan result, window-1)
+			wg      sync.WaitGroup
+		)
+
+		go func() {
+			defer close(pending)
+
+			for _, id := range ids {
+				ch := make(chan result, 1)
+
+				select {
+				case pending <- ch:
+				case <-ctx.Done():
+					return
+				}
+
+				wg.Add(1)
+
+				task := func() {
+					defer wg.Done()
+
+					item, err := s.Get(ctx, id)
+					if err != nil {
+						err = &ItemError{ID: id, Err: err}
+					}
+
+					// ch has room for the single result, so the send never blocks,
+					// even if the consumer has stopped reading.
+					ch <- result{item: item, err: err}
+				}
+
+				if s.pool != nil {
+					s.pool.submit(task)
+				} else {
+					go task()
+				}
+			}
+		}()
+
+		for ch := range pending {
+			r := <-ch
+
+			if errors.Is(r.err, ErrNotFound) || r.err == nil && !s.keep(r.item, filter) {
+				continue
+			}
+
+			if !yield(r.item, r.err) {
+				break
+			}
+		}
+
+		cancel()
+
+		// Wait for the dispatcher and all workers to stop before returning.
+		for range pending {
+		}
+
+		wg.Wait()
+	}
 }
 
 // UserService provides methods to retrieve data about Hacker News users.
 type UserService struct {
 	client *http.Client
+	opts   *options
 	items  *ItemService
 }
 
 // Get returns a User with the given name.
 func (s *UserService) Get(ctx context.Context, username string) (User, error) {
-	return Fetch[User](ctx, s.client, http.MethodGet, ("/user/" + username))
+	user, err := fetch[User](ctx, s.client, s.opts, http.MethodGet, ("/user/" + username))
+	if err != nil {
+		return User{}, err
+	}
+
+	if s.opts.rawText {
+		return user, nil
+	}
+
+	return user.Unescaped(), nil
+}
+
+// Summary returns the summary of the profile of the user with the given name.
+// It is computed from the user data only, so none of the submitted items are fetched.
+func (s *UserService) Summary(ctx context.Context, username string) (UserSummary, error) {
+	user, err := s.Get(ctx, username)
+	if err != nil {
+		return UserSummary{}, err
+	}
+
+	return user.Summary(time.Now()), nil
+}
+
+// List returns a list of users with the given names, in the same order as usernames.
+// Unlike ItemService.List, it fails if any of the users can't be fetched, including the users that are not found.
+func (s *UserService) List(ctx context.Context, usernames []string) ([]User, error) {
+	if len(usernames) == 0 {
+		return []User{}, nil
+	}
+
+	users := make([]User, len(usernames))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.opts.workerLimit())
+
+	for i, username := range usernames {
+		g.Go(func() error {
+			user, err := s.Get(ctx, username)
+			if err != nil {
+				return err
+			}
+
+			users[i] = user
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
 }
 
 // Items returns the items submitted by the user with the given name, filtered if necessary.
-func (s *UserService) Items(ctx context.Context, username string, filter func(Item) bool) ([]Item, error) {
+//
+// Only the most recent limit submissions are fetched (before the filter is applied),
+// and a limit of 0 or less means all submissions are fetched.
+func (s *UserService) Items(ctx context.Context, username string, filter func(Item) bool, limit int) ([]Item, error) {
 	user, err := s.Get(ctx, username)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.items.List(ctx, user.Submitted, filter)
+	return s.items.List(ctx, page(user.Submitted, 0, uint(max(limit, 0))), filter)
+}
+
+// Profile returns the user with the given name along with the items submitted by the user,
+// filtered if necessary, like Get and Items, but fetching the user only once.
+//
+// Only the most recent limit submissions are fetched (before the filter is applied),
+// and a limit of 0 or less means all submissions are fetched.
+func (s *UserService) Profile(ctx context.Context, username string, limit int, filter func(Item) bool) (User, []Item, error) {
+	user, err := s.Get(ctx, username)
+	if err != nil {
+		return User{}, nil, err
+	}
+
+	items, err := s.items.List(ctx, page(user.Submitted, 0, uint(max(limit, 0))), filter)
+	if err != nil {
+		return User{}, nil, err
+	}
+
+	return user, items, nil
+}
+
+// Stream returns an iterator over the items submitted by the user with the given name, filtered if necessary.
+// The items are fetched lazily and yielded in the order of submission (the most recent first),
+// skipping the items that are not found (e.g., the removed submissions). The number of items fetched ahead of the consumer is bounded by the worker limit.
+// Stopping the iteration cancels all outstanding requests.
+func (s *UserService) Stream(ctx context.Context, username string, filter func(Item) bool) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		user, err := s.Get(ctx, username)
+		if err != nil {
+			yield(Item{}, err)
+			return
+		}
+
+		for item, err := range s.items.streamOrdered(ctx, user.Submitted, filter) {
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
 }
 
 // Comments returns the comments submitted by the user with the given name.
-func (s *UserService) Comments(ctx context.Context, username string) ([]Comment, error) {
+// Only the most recent limit submissions are checked, and a limit of 0 or less means all submissions.
+func (s *UserService) Comments(ctx context.Context, username string, limit int) ([]Comment, error) {
 	items, err := s.Items(ctx, username, func(item Item) bool {
 		return item.Type == CommentType
-	})
-	
+	}, limit)
+
 	if err != nil {
 		return nil, err
 	}
@@ -348,10 +1078,11 @@ func (s *UserService) Comments(ctx context.Context, username string) ([]Comment,
 }
 
 // Stories returns the stories submitted by the user with the given name.
-func (s *UserService) Stories(ctx context.Context, username string) ([]Story, error) {
+// Only the most recent limit submissions are checked, and a limit of 0 or less means all submissions.
+func (s *UserService) Stories(ctx context.Context, username string, limit int) ([]Story, error) {
 	items, err := s.Items(ctx, username, func(item Item) bool {
 		return item.Type == StoryType
-	})
+	}, limit)
 
 	if err != nil {
 		return nil, err
@@ -360,11 +1091,30 @@ func (s *UserService) Stories(ctx context.Context, username string) ([]Story, er
 	return ToList[Story](items), nil
 }
 
+// TopStories returns the n stories with the highest score submitted by the user with the given name,
+// sorted by score in descending order (keeping the original order in case of a tie).
+// Only the most recent 1000 submissions are checked, and a value of n of 0 or less means all the stories.
+func (s *UserService) TopStories(ctx context.Context, username string, n int) ([]Story, error) {
+	stories, err := s.Stories(ctx, username, maxUserTopStories)
+	if err != nil {
+		return nil, err
+	}
+
+	SortScore(stories, Descending)
+
+	if n > 0 && n < len(stories) {
+		stories = stories[:n]
+	}
+
+	return stories, nil
+}
+
 // Jobs returns the jobs submitted by the user with the given name.
-func (s *UserService) Jobs(ctx context.Context, username string) ([]Job, error) {
+// Only the most recent limit submissions are checked, and a limit of 0 or less means all submissions.
+func (s *UserService) Jobs(ctx context.Context, username string, limit int) ([]Job, error) {
 	items, err := s.Items(ctx, username, func(item Item) bool {
 		return item.Type == JobType
-	})
+	}, limit)
 
 	if err != nil {
 		return nil, err
@@ -374,10 +1124,11 @@ func (s *UserService) Jobs(ctx context.Context, username string) ([]Job, error)
 }
 
 // Asks returns the asks submitted by the user with the given name.
-func (s *UserService) Asks(ctx context.Context, username string) ([]Ask, error) {
+// Only the most recent limit submissions are checked, and a limit of 0 or less means all submissions.
+func (s *UserService) Asks(ctx context.Context, username string, limit int) ([]Ask, error) {
 	items, err := s.Items(ctx, username, func(item Item) bool {
 		return item.Type == AskType
-	})
+	}, limit)
 
 	if err != nil {
 		return nil, err
@@ -387,10 +1138,11 @@ func (s *UserService) Asks(ctx context.Context, username string) ([]Ask, error)
 }
 
 // Polls returns the polls submitted by the user with the given name.
-func (s *UserService) Polls(ctx context.Context, username string) ([]Poll, error) {
+// Only the most recent limit submissions are checked, and a limit of 0 or less means all submissions.
+func (s *UserService) Polls(ctx context.Context, username string, limit int) ([]Poll, error) {
 	items, err := s.Items(ctx, username, func(item Item) bool {
 		return item.Type == PollType
-	})
+	}, limit)
 
 	if err != nil {
 		return nil, err
@@ -400,10 +1152,11 @@ func (s *UserService) Polls(ctx context.Context, username string) ([]Poll, error
 }
 
 // PollOptions returns the poll options submitted by the user with the given name.
-func (s *UserService) PollOptions(ctx context.Context, username string) ([]PollOption, error) {
+// Only the most recent limit submissions are checked, and a limit of 0 or less means all submissions.
+func (s *UserService) PollOptions(ctx context.Context, username string, limit int) ([]PollOption, error) {
 	items, err := s.Items(ctx, username, func(item Item) bool {
 		return item.Type == PollOptionType
-	})
+	}, limit)
 
 	if err != nil {
 		return nil, err
@@ -412,9 +1165,53 @@ func (s *UserService) PollOptions(ctx context.Context, username string) ([]PollO
 	return ToList[PollOption](items), nil
 }
 
+// CommentContext is a comment along with the top-level item (e.g., a story or an ask) of its thread.
+type CommentContext struct {
+	Comment   Comment
+	RootID    uint
+	RootTitle string
+}
+
+// CommentsWithContext returns the most recent limit comments submitted by the user with the given name, along with
+// the IDs and titles of their top-level items. A limit of 0 or less means all comments. The submissions are fetched
+// in the order of submission until limit comments have been found, so the other submissions are not checked.
+//
+// The top-level items are resolved concurrently, fetching each item of the parent chains only once (see
+// ItemService.AnnotateRoots). If the top-level item of a comment is not found, its RootID and RootTitle are left empty.
+func (s *UserService) CommentsWithContext(ctx context.Context, username string, limit int) ([]CommentContext, error) {
+	var comments []Comment
+
+	for item, err := range s.Stream(ctx, username, func(item Item) bool { return item.Type == CommentType }) {
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, ToComment(item))
+
+		if len(comments) == limit {
+			break
+		}
+	}
+
+	roots, err := s.items.roots(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]CommentContext, len(comments))
+
+	for i, comment := range comments {
+		root := roots[comment.Parent]
+		list[i] = CommentContext{Comment: comment, RootID: root.ID, RootTitle: root.Title}
+	}
+
+	return list, nil
+}
+
 // LiveService provides methods to retrieve data about recent updates.
 type LiveService struct {
 	client *http.Client
+	opts   *options
 	items  *ItemService
 }
 
@@ -433,69 +1230,206 @@ func (s *LiveService) Recent(ctx context.Context, offset uint) ([]Item, error) {
 	return s.items.List(ctx, ids, nil)
 }
 
+// RecentSince returns the items published after the item with lastID, filtered if necessary, in ascending order of ID.
+// At most maxRecentSince items after lastID are fetched in a single call, so a crawler that got behind
+// can catch up by calling it again with the ID of the last returned item. If there are no new items,
+// an empty slice is returned.
+func (s *LiveService) RecentSince(ctx context.Context, lastID uint, filter func(Item) bool) ([]Item, error) {
+	latest, err := s.MaxID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastID >= latest {
+		return []Item{}, nil
+	}
+
+	latest = min(latest, lastID+maxRecentSince)
+
+	ids := make([]uint, 0, latest-lastID)
+	for id := lastID + 1; id <= latest; id++ {
+		ids = append(ids, id)
+	}
+
+	return s.items.List(ctx, ids, filter)
+}
+
+// Descending returns an iterator over the items in descending order of ID, starting from the most recently
+// published item. The items are fetched lazily, one at a time, as the iteration proceeds.
+// IDs that are not found are skipped, and the iteration stops when ctx is canceled.
+func (s *LiveService) Descending(ctx context.Context) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		latest, err := s.MaxID(ctx)
+		if err != nil {
+			yield(Item{}, err)
+			return
+		}
+
+		for id := latest; id > 0; id-- {
+			item, err := s.items.Get(ctx, id)
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+
+			if ctx.Err() != nil {
+				yield(Item{}, ctx.Err())
+				return
+			}
+
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
 // MaxID returns the ID of the most recently published item.
 func (s *LiveService) MaxID(ctx context.Context) (uint, error) {
-	return Fetch[uint](ctx, s.client, http.MethodGet, "/maxitem")
+	maxID, err := fetch[id](ctx, s.client, s.opts, http.MethodGet, "/maxitem")
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(maxID), nil
 }
 
 // New returns a list of IDs for the new stories.
 func (s *LiveService) New(ctx context.Context) ([]uint, error) {
-	return Fetch[[]uint](ctx, s.client, http.MethodGet, "/newstories")
+	return s.Stories(ctx, CategoryNew)
 }
 
 // NewList returns a list of items for the new stories, filtered if necessary.
 func (s *LiveService) NewList(ctx context.Context, filter func(Item) bool) ([]Item, error) {
+	return s.StoriesList(ctx, CategoryNew, filter)
+}
+
+// NewListPage returns a page of items for the new stories, filtered if necessary.
+// Only the items at positions [offset, offset+limit) of the list are fetched, and a limit of 0 means no limit.
+func (s *LiveService) NewListPage(ctx context.Context, offset, limit uint, filter func(Item) bool) ([]Item, error) {
+	return s.storiesPage(ctx, CategoryNew, offset, limit, filter)
+}
+
+// NewStories returns the new stories, converted to Story structs. Only the first limit IDs are fetched
+// (a limit of 0 means all of them), and the items of other types (e.g., jobs) are excluded.
+func (s *LiveService) NewStories(ctx context.Context, limit uint) ([]Story, error) {
 	ids, err := s.New(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.items.List(ctx, ids, filter)
+	return ListAs[Story](ctx, s.items, page(ids, 0, limit), nil)
 }
 
 // Top returns a list of IDs for the top stories.
 func (s *LiveService) Top(ctx context.Context) ([]uint, error) {
-	return Fetch[[]uint](ctx, s.client, http.MethodGet, "/topstories")
+	return s.Stories(ctx, CategoryTop)
 }
 
 // TopList returns a list of items for the top stories, filtered if necessary.
 func (s *LiveService) TopList(ctx context.Context, filter func(Item) bool) ([]Item, error) {
+	return s.StoriesList(ctx, CategoryTop, filter)
+}
+
+// TopListPage returns a page of items for the top stories, filtered if necessary.
+// Only the items at positions [offset, offset+limit) of the list are fetched, and a limit of 0 means no limit.
+func (s *LiveService) TopListPage(ctx context.Context, offset, limit uint, filter func(Item) bool) ([]Item, error) {
+	return s.storiesPage(ctx, CategoryTop, offset, limit, filter)
+}
+
+// TopStories returns the top stories, converted to Story structs. Only the first limit IDs are fetched
+// (a limit of 0 means all of them), and the items of other types (e.g., jobs) are excluded.
+func (s *LiveService) TopStories(ctx context.Context, limit uint) ([]Story, error) {
 	ids, err := s.Top(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.items.List(ctx, ids, filter)
+	return ListAs[Story](ctx, s.items, page(ids, 0, limit), nil)
+}
+
+// TopN returns the first n items for the top stories that match the filter.
+//
+// The top stories are fetched in batches of n items (or the worker limit, if it's larger, so all the workers
+// are kept busy), moving down the list until n matching items are found or the list is exhausted.
+func (s *LiveService) TopN(ctx context.Context, n int, filter func(Item) bool) ([]Item, error) {
+	if n <= 0 {
+		return []Item{}, nil
+	}
+
+	ids, err := s.Top(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	size := s.opts.workerLimit()
+	if size <= 0 {
+		size = defaultMaxWorkers
+	}
+
+	items := make([]Item, 0, n)
+
+	for batch := range slices.Chunk(ids, max(n, size)) {
+		list, err := s.items.List(ctx, batch, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, list...)
+
+		if len(items) >= n {
+			return items[:n], nil
+		}
+	}
+
+	return items, nil
 }
 
 // Best returns a list of IDs for the best stories.
 func (s *LiveService) Best(ctx context.Context) ([]uint, error) {
-	return Fetch[[]uint](ctx, s.client, http.MethodGet, "/beststories")
+	return s.Stories(ctx, CategoryBest)
 }
 
 // BestList returns a list of items for the best stories, filtered if necessary.
 func (s *LiveService) BestList(ctx context.Context, filter func(Item) bool) ([]Item, error) {
+	return s.StoriesList(ctx, CategoryBest, filter)
+}
+
+// BestListPage returns a page of items for the best stories, filtered if necessary.
+// Only the items at positions [offset, offset+limit) of the list are fetched, and a limit of 0 means no limit.
+func (s *LiveService) BestListPage(ctx context.Context, offset, limit uint, filter func(Item) bool) ([]Item, error) {
+	return s.storiesPage(ctx, CategoryBest, offset, limit, filter)
+}
+
+// BestStories returns the best stories, converted to Story structs. Only the first limit IDs are fetched
+// (a limit of 0 means all of them), and the items of other types (e.g., jobs) are excluded.
+func (s *LiveService) BestStories(ctx context.Context, limit uint) ([]Story, error) {
 	ids, err := s.Best(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.items.List(ctx, ids, filter)
+	return ListAs[Story](ctx, s.items, page(ids, 0, limit), nil)
 }
 
 // Ask returns a list of IDs for the asks.
 func (s *LiveService) Ask(ctx context.Context) ([]uint, error) {
-	return Fetch[[]uint](ctx, s.client, http.MethodGet, "/askstories")
+	return s.Stories(ctx, CategoryAsk)
 }
 
 // AskList returns a list of items for the asks, filtered if necessary.
 func (s *LiveService) AskList(ctx context.Context, filter func(Item) bool) ([]Ask, error) {
-	ids, err := s.Ask(ctx)
+	items, err := s.StoriesList(ctx, CategoryAsk, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	items, err := s.items.List(ctx, ids, filter)
+	return ToList[Ask](items), nil
+}
+
+// AskListPage returns a page of items for the asks, filtered if necessary.
+// Only the items at positions [offset, offset+limit) of the list are fetched, and a limit of 0 means no limit.
+func (s *LiveService) AskListPage(ctx context.Context, offset, limit uint, filter func(Item) bool) ([]Ask, error) {
+	items, err := s.storiesPage(ctx, CategoryAsk, offset, limit, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -505,17 +1439,23 @@ func (s *LiveService) AskList(ctx context.Context, filter func(Item) bool) ([]As
 
 // Show returns a list of IDs for the shows.
 func (s *LiveService) Show(ctx context.Context) ([]uint, error) {
-	return Fetch[[]uint](ctx, s.client, http.MethodGet, "/showstories")
+	return s.Stories(ctx, CategoryShow)
 }
 
 // ShowList returns a list of items for the shows, filtered if necessary.
 func (s *LiveService) ShowList(ctx context.Context, filter func(Item) bool) ([]Story, error) {
-	ids, err := s.Show(ctx)
+	items, err := s.StoriesList(ctx, CategoryShow, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	items, err := s.items.List(ctx, ids, filter)
+	return ToList[Story](items), nil
+}
+
+// ShowListPage returns a page of items for the shows, filtered if necessary.
+// Only the items at positions [offset, offset+limit) of the list are fetched, and a limit of 0 means no limit.
+func (s *LiveService) ShowListPage(ctx context.Context, offset, limit uint, filter func(Item) bool) ([]Story, error) {
+	items, err := s.storiesPage(ctx, CategoryShow, offset, limit, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -525,17 +1465,23 @@ func (s *LiveService) ShowList(ctx context.Context, filter func(Item) bool) ([]S
 
 // Job returns a list of IDs for the jobs.
 func (s *LiveService) Job(ctx context.Context) ([]uint, error) {
-	return Fetch[[]uint](ctx, s.client, http.MethodGet, "/jobstories")
+	return s.Stories(ctx, CategoryJob)
 }
 
 // JobList returns a list of items for the jobs, filtered if necessary.
 func (s *LiveService) JobList(ctx context.Context, filter func(Item) bool) ([]Job, error) {
-	ids, err := s.Job(ctx)
+	items, err := s.StoriesList(ctx, CategoryJob, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	items, err := s.items.List(ctx, ids, filter)
+	return ToList[Job](items), nil
+}
+
+// JobListPage returns a page of items for the jobs, filtered if necessary.
+// Only the items at positions [offset, offset+limit) of the list are fetched, and a limit of 0 means no limit.
+func (s *LiveService) JobListPage(ctx context.Context, offset, limit uint, filter func(Item) bool) ([]Job, error) {
+	items, err := s.storiesPage(ctx, CategoryJob, offset, limit, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -545,7 +1491,7 @@ func (s *LiveService) JobList(ctx context.Context, filter func(Item) bool) ([]Jo
 
 // Update returns an Update containing IDs of updated items and profiles.
 func (s *LiveService) Update(ctx context.Context) (Update, error) {
-	return Fetch[Update](ctx, s.client, http.MethodGet, "/updates")
+	return fetch[Update](ctx, s.client, s.opts, http.MethodGet, "/updates")
 }
 
 // UpdateList returns a list of updated items, filtered if necessary.
@@ -558,36 +1504,18 @@ func (s *LiveService) UpdateList(ctx context.Context, filter func(Item) bool) ([
 	return s.items.List(ctx, update.Items, filter)
 }
 
-// Fetch sends an HTTP request to the Hacker News API and returns a value of the specified type.
-func Fetch[T any](ctx context.Context, client *http.Client, method, url string) (T, error) {
-	var t T
-
-	req, err := http.NewRequestWithContext(ctx, method, (baseURL + url + ".json"), nil)
-	if err != nil {
-		return t, fmt.Errorf("create HTTP request: %w", err)
+// page returns the IDs at positions [offset, offset+limit) of ids.
+// A limit of 0 means there is no limit, and an offset beyond the end of ids returns an empty slice.
+func page(ids []uint, offset, limit uint) []uint {
+	if offset >= uint(len(ids)) {
+		return []uint{}
 	}
 
-	req.Header.Add("User-Agent", userAgent)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return t, fmt.Errorf("send HTTP request: %w", err)
-	}
-	defer resp.Body.Close()
+	ids = ids[offset:]
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return t, fmt.Errorf("read response JSON: %w", err)
-	}
-
-	if string(body) == "null" {
-		return t, ErrNotFound
-	}
-
-	err = json.Unmarshal(body, &t)
-	if err != nil {
-		return t, fmt.Errorf("decode response JSON: %w", err)
+	if limit > 0 && limit < uint(len(ids)) {
+		ids = ids[:limit]
 	}
 
-	return t, nil
+	return ids
 }
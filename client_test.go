@@ -0,0 +1,1555 @@
+package hn_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+// deletedSubmissions returns a fixture of a user whose submissions 2 and 4 are no longer available,
+// and the submission 5 fails with a server error if failing is true.
+func deletedSubmissions(failing bool) *fixture {
+	f := &fixture{
+		items: itemsOf(newStory(1, 10), newStory(3, 30), newStory(5, 50)),
+		users: map[string]hn.User{"pg": {ID: "pg", Submitted: []uint{1, 2, 3, 4, 5}}},
+	}
+
+	if failing {
+		f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+			if r.URL.Path != "/item/5.json" {
+				return false
+			}
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return true
+		}
+	}
+
+	return f
+}
+
+func TestListSkipsNotFound(t *testing.T) {
+	client := newTestClient(t, deletedSubmissions(false))
+
+	items, err := client.Items.List(context.Background(), []uint{1, 2, 3, 4, 5}, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if got, want := hn.IDsOf(items), []uint{1, 3, 5}; !slices.Equal(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	items, err = client.Users.Items(context.Background(), "pg", nil, 0)
+	if err != nil {
+		t.Fatalf("Users.Items() error = %v", err)
+	}
+
+	if got, want := hn.IDsOf(items), []uint{1, 3, 5}; !slices.Equal(got, want) {
+		t.Errorf("Users.Items() = %v, want %v", got, want)
+	}
+}
+
+func TestListFailsOnOtherErrors(t *testing.T) {
+	client := newTestClient(t, deletedSubmissions(true))
+
+	_, err := client.Items.List(context.Background(), []uint{1, 2, 3, 4, 5}, nil)
+
+	var itemErr *hn.ItemError
+	if !errors.As(err, &itemErr) || itemErr.ID != 5 {
+		t.Fatalf("List() error = %v, want an *ItemError for item 5", err)
+	}
+}
+
+func TestStreamSkipsNotFound(t *testing.T) {
+	client := newTestClient(t, deletedSubmissions(true))
+
+	var (
+		ids    []uint
+		failed []uint
+	)
+
+	for item, err := range client.Items.Stream(context.Background(), []uint{1, 2, 3, 4, 5}, nil) {
+		var itemErr *hn.ItemError
+		if errors.As(err, &itemErr) {
+			failed = append(failed, itemErr.ID)
+			continue
+		}
+
+		ids = append(ids, item.ID)
+	}
+
+	slices.Sort(ids)
+
+	if want := []uint{1, 3}; !slices.Equal(ids, want) {
+		t.Errorf("Stream() items = %v, want %v", ids, want)
+	}
+
+	if want := []uint{5}; !slices.Equal(failed, want) {
+		t.Errorf("Stream() errors for items %v, want %v", failed, want)
+	}
+}
+
+func TestUserStreamSkipsNotFound(t *testing.T) {
+	client := newTestClient(t, deletedSubmissions(true))
+
+	var (
+		ids    []uint
+		failed []uint
+	)
+
+	for item, err := range client.Users.Stream(context.Background(), "pg", nil) {
+		var itemErr *hn.ItemError
+		if errors.As(err, &itemErr) {
+			failed = append(failed, itemErr.ID)
+			continue
+		}
+
+		ids = append(ids, item.ID)
+	}
+
+	if want := []uint{1, 3}; !slices.Equal(ids, want) {
+		t.Errorf("Stream() items = %v, want %v", ids, want)
+	}
+
+	if want := []uint{5}; !slices.Equal(failed, want) {
+		t.Errorf("Stream() errors for items %v, want %v", failed, want)
+	}
+}
+
+func TestUserStreamWindow(t *testing.T) {
+	for _, window := range []int{1, 3} {
+		t.Run("window "+strconv.Itoa(window), func(t *testing.T) {
+			var probe inFlight
+
+			f := topStories(12)
+			f.users = map[string]hn.User{"pg": {ID: "pg", Submitted: idRange(1, 12)}}
+			f.handler = probe.handler(5 * time.Millisecond)
+
+			client := newTestClient(t, f, hn.WithMaxWorkers(window))
+
+			var ids []uint
+
+			for item, err := range client.Users.Stream(context.Background(), "pg", nil) {
+				if err != nil {
+					t.Fatalf("Stream() error = %v", err)
+				}
+
+				ids = append(ids, item.ID)
+			}
+
+			if want := idRange(1, 12); !slices.Equal(ids, want) {
+				t.Errorf("Stream() items = %v, want %v", ids, want)
+			}
+
+			if n := probe.max(); n > window {
+				t.Errorf("%d requests in flight, want at most %d", n, window)
+			}
+		})
+	}
+}
+
+// topStories returns a fixture with the top stories 1 to n, where the story i has a score of i.
+func topStories(n uint) *fixture {
+	f := &fixture{items: make(map[uint]hn.Item), lists: make(map[string][]uint)}
+
+	for id := uint(1); id <= n; id++ {
+		f.items[id] = newStory(id, int(id))
+		f.lists[string(hn.CategoryTop)] = append(f.lists[string(hn.CategoryTop)], id)
+	}
+
+	return f
+}
+
+// itemRequests returns the number of the item requests received by the fixture (excluding the lists).
+func itemRequests(f *fixture) int {
+	var n int
+	for id := range f.items {
+		n += f.count("/item/" + strconv.Itoa(int(id)) + ".json")
+	}
+
+	return n
+}
+
+func TestTopN(t *testing.T) {
+	f := topStories(30)
+	client := newTestClient(t, f, hn.WithMaxWorkers(10))
+
+	// Only the stories with a score of 25 or more match, so three batches of 10 are fetched.
+	items, err := client.Live.TopN(context.Background(), 3, func(item hn.Item) bool { return item.Score >= 25 })
+	if err != nil {
+		t.Fatalf("TopN() error = %v", err)
+	}
+
+	if got, want := hn.IDsOf(items), []uint{25, 26, 27}; !slices.Equal(got, want) {
+		t.Errorf("TopN() = %v, want %v", got, want)
+	}
+
+	if n := itemRequests(f); n != 30 {
+		t.Errorf("got %d item requests, want 30", n)
+	}
+}
+
+func TestTopNBatchSize(t *testing.T) {
+	f := topStories(30)
+	client := newTestClient(t, f, hn.WithMaxWorkers(10))
+
+	// A small n still fetches a whole batch of the worker limit at once.
+	items, err := client.Live.TopN(context.Background(), 2, nil)
+	if err != nil {
+		t.Fatalf("TopN() error = %v", err)
+	}
+
+	if got, want := hn.IDsOf(items), []uint{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("TopN() = %v, want %v", got, want)
+	}
+
+	if n := itemRequests(f); n != 10 {
+		t.Errorf("got %d item requests, want 10", n)
+	}
+
+	// The list is exhausted before n items are found.
+	items, err = client.Live.TopN(context.Background(), 50, nil)
+	if err != nil {
+		t.Fatalf("TopN() error = %v", err)
+	}
+
+	if len(items) != 30 {
+		t.Errorf("got %d items, want 30", len(items))
+	}
+}
+
+func TestListPartial(t *testing.T) {
+	f := &fixture{items: itemsOf(newStory(1, 10), newStory(3, 30), newStory(5, 50))}
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		switch r.URL.Path {
+		case "/item/1.json":
+			// The first item is answered last, so the order of the results can't depend on the order of the responses.
+			time.Sleep(20 * time.Millisecond)
+		case "/item/4.json":
+			dropConnection(w)
+			return true
+		}
+
+		return false
+	}
+
+	client := newTestClient(t, f)
+
+	items, errs := client.Items.ListPartial(context.Background(), []uint{1, 2, 3, 4, 5}, nil)
+
+	if got, want := hn.IDsOf(items), []uint{1, 3, 5}; !slices.Equal(got, want) {
+		t.Errorf("ListPartial() items = %v, want %v", got, want)
+	}
+
+	if len(errs) != 5 {
+		t.Fatalf("got %d errors, want 5 aligned with the IDs", len(errs))
+	}
+
+	for i, err := range errs {
+		id := uint(i + 1)
+
+		switch id {
+		case 2:
+			if !errors.Is(err, hn.ErrNotFound) {
+				t.Errorf("error for item 2 = %v, want ErrNotFound", err)
+			}
+		case 4:
+			var itemErr *hn.ItemError
+			if !errors.As(err, &itemErr) || itemErr.ID != 4 || errors.Is(err, hn.ErrNotFound) {
+				t.Errorf("error for item 4 = %v, want a network *ItemError", err)
+			}
+		default:
+			if err != nil {
+				t.Errorf("error for item %d = %v, want nil", id, err)
+			}
+		}
+	}
+}
+
+func TestListPartialNoErrors(t *testing.T) {
+	client := newTestClient(t, &fixture{items: itemsOf(newStory(1, 10), newStory(2, 20))})
+
+	items, errs := client.Items.ListPartial(context.Background(), []uint{2, 1}, hn.MinScore(15))
+	if errs != nil {
+		t.Errorf("ListPartial() errors = %v, want nil", errs)
+	}
+
+	if got, want := hn.IDsOf(items), []uint{2}; !slices.Equal(got, want) {
+		t.Errorf("ListPartial() items = %v, want %v", got, want)
+	}
+
+	items, errs = client.Items.ListPartial(context.Background(), nil, nil)
+	if items == nil || len(items) != 0 || errs != nil {
+		t.Errorf("ListPartial(nil) = %v, %v, want an empty list", items, errs)
+	}
+}
+
+func TestListWorkerLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []hn.Option
+		limit int
+	}{
+		{"default", nil, 100},
+		{"option", []hn.Option{hn.WithMaxWorkers(5)}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var probe inFlight
+
+			f := topStories(150)
+			f.handler = probe.handler(20 * time.Millisecond)
+
+			client := newTestClient(t, f, tt.opts...)
+
+			items, err := client.Items.List(context.Background(), idRange(1, 150), nil)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(items) != 150 {
+				t.Errorf("got %d items, want 150", len(items))
+			}
+
+			if n := probe.max(); n > tt.limit {
+				t.Errorf("%d requests in flight, want at most %d", n, tt.limit)
+			}
+		})
+	}
+}
+
+func TestListWithoutWorkerLimit(t *testing.T) {
+	f := &fixture{items: itemsOf(newStory(1, 1), newStory(2, 2), newStory(3, 3))}
+
+	// A limit of 0 must not block the workers forever.
+	client := newTestClient(t, f, hn.WithMaxWorkers(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	items, err := client.Items.List(ctx, []uint{1, 2, 3}, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(items) != 3 {
+		t.Errorf("got %d items, want 3", len(items))
+	}
+}
+
+func TestListCancelDoesNotLeak(t *testing.T) {
+	var aborted atomic.Int32
+
+	f := slowItems(100, 3, &aborted)
+	client := newTestClient(t, f, hn.WithMaxWorkers(10))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		// Cancel in the middle of the fetch, when all the workers are waiting for the slow items.
+		for f.total() < 13 {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		cancel()
+	}()
+
+	if _, err := client.Items.List(ctx, idRange(1, 100), nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("List() error = %v, want context.Canceled", err)
+	}
+
+	waitFor(t, func() bool { return leaked() == "" })
+	waitFor(t, func() bool { return int(aborted.Load()) == f.total()-3 })
+}
+
+func TestListCancelWithFetchedItems(t *testing.T) {
+	client := newTestClient(t, topStories(200), hn.WithMaxWorkers(20))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var kept atomic.Int32
+
+	// The filter runs in the workers, so the context is canceled while the other workers have items to store.
+	filter := func(hn.Item) bool {
+		if kept.Add(1) == 50 {
+			cancel()
+		}
+
+		return true
+	}
+
+	if _, err := client.Items.List(ctx, idRange(1, 200), filter); !errors.Is(err, context.Canceled) {
+		t.Fatalf("List() error = %v, want context.Canceled", err)
+	}
+
+	waitFor(t, func() bool { return leaked() == "" })
+}
+
+func BenchmarkList(b *testing.B) {
+	ids := idRange(1, 1000)
+
+	benchmarks := []struct {
+		name string
+		opts []hn.Option
+	}{
+		{"server", nil},
+		{"server/10 workers", []hn.Option{hn.WithMaxWorkers(10)}},
+		// The items are cached after the first iteration, so the collection of the results dominates.
+		{"cache", []hn.Option{hn.WithItemCache(len(ids), 0)}},
+		{"cache/10 workers", []hn.Option{hn.WithItemCache(len(ids), 0), hn.WithMaxWorkers(10)}},
+		{"server/pool of 10 workers", []hn.Option{hn.WithWorkerPool(10)}},
+		{"cache/pool of 10 workers", []hn.Option{hn.WithItemCache(len(ids), 0), hn.WithWorkerPool(10)}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			client := newTestClient(b, topStories(uint(len(ids))), bm.opts...)
+
+			for b.Loop() {
+				if _, err := client.Items.List(context.Background(), ids, nil); err != nil {
+					b.Fatalf("List() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetNullFields(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"null", `{"id":1,"type":"comment","by":null,"score":null,"time":null,"kids":null,"parent":null,"text":null}`},
+		{"missing", `{"id":1,"type":"comment"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{}
+			f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+				return true
+			}
+
+			item, err := newTestClient(t, f).Items.Get(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if item.ID != 1 || item.By != "" || item.Score != 0 || !item.Time.IsZero() || item.Kids != nil || item.Parent != 0 || item.Text != "" {
+				t.Errorf("Get() = %+v, want only the ID and the type", item)
+			}
+		})
+	}
+}
+
+func TestWithRawText(t *testing.T) {
+	story := newStory(1, 10)
+	story.Title = "Rust &amp; Go"
+	story.Text = "It&#x27;s &lt;fast&gt;"
+
+	f := &fixture{
+		items: itemsOf(story),
+		users: map[string]hn.User{"pg": {ID: "pg", About: "Y &amp; HN"}},
+	}
+
+	tests := []struct {
+		name  string
+		opts  []hn.Option
+		title string
+		text  string
+		about string
+	}{
+		{"unescaped", nil, "Rust & Go", "It's <fast>", "Y & HN"},
+		{"raw text", []hn.Option{hn.WithRawText()}, "Rust &amp; Go", "It&#x27;s &lt;fast&gt;", "Y &amp; HN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, f, tt.opts...)
+
+			item, err := client.Items.Get(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Items.Get() error = %v", err)
+			}
+
+			if item.Title != tt.title || item.Text != tt.text {
+				t.Errorf("Items.Get() = %q, %q, want %q, %q", item.Title, item.Text, tt.title, tt.text)
+			}
+
+			user, err := client.Users.Get(context.Background(), "pg")
+			if err != nil {
+				t.Fatalf("Users.Get() error = %v", err)
+			}
+
+			if user.About != tt.about {
+				t.Errorf("About = %q, want %q", user.About, tt.about)
+			}
+		})
+	}
+}
+
+func TestGetDeletedDead(t *testing.T) {
+	deleted := newComment(2, 1)
+	deleted.Deleted = true
+
+	dead := newComment(3, 1)
+	dead.Dead = true
+
+	f := &fixture{items: itemsOf(newComment(1, 0), deleted, dead)}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		id      uint
+		deleted bool
+		dead    bool
+	}{
+		{1, false, false},
+		{2, true, false},
+		{3, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(itoa(tt.id), func(t *testing.T) {
+			item, err := client.Items.Get(context.Background(), tt.id)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if item.Deleted != tt.deleted || item.Dead != tt.dead {
+				t.Errorf("Deleted, Dead = %v, %v, want %v, %v", item.Deleted, item.Dead, tt.deleted, tt.dead)
+			}
+
+			// The fields are common to all items, so they're kept by the conversions.
+			if c := hn.ToComment(item); c.Deleted != tt.deleted || c.Dead != tt.dead {
+				t.Errorf("ToComment() Deleted, Dead = %v, %v, want %v, %v", c.Deleted, c.Dead, tt.deleted, tt.dead)
+			}
+		})
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		json string
+	}{
+		{"zero", time.Time{}, `0`},
+		{"unix seconds", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), `1714564800`},
+		{"epoch", time.Unix(1, 0).UTC(), `1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(hn.Timestamp{Time: tt.time})
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			if string(data) != tt.json {
+				t.Errorf("Marshal() = %s, want %s", data, tt.json)
+			}
+
+			var got hn.Timestamp
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			if !got.Equal(tt.time) {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.time)
+			}
+		})
+	}
+}
+
+func TestTimestampUTC(t *testing.T) {
+	// The decoded times must not depend on the time zone of the host.
+	local := time.Local
+	time.Local = time.FixedZone("UTC+5", 5*60*60)
+	t.Cleanup(func() { time.Local = local })
+
+	item := newStory(1, 10)
+	item.Time = hn.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)}
+
+	client := newTestClient(t, &fixture{items: itemsOf(item)})
+
+	decode := func() (hn.Timestamp, error) {
+		var ts hn.Timestamp
+		err := json.Unmarshal([]byte(`1714564800`), &ts)
+		return ts, err
+	}
+
+	tests := []struct {
+		name string
+		get  func() (hn.Timestamp, error)
+		want time.Time
+	}{
+		{"unmarshal", decode, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+		{"item", func() (hn.Timestamp, error) {
+			item, err := client.Items.Get(context.Background(), 1)
+			return item.Time, err
+		}, time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if got.Location() != time.UTC {
+				t.Errorf("location = %v, want UTC", got.Location())
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("time = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAs(t *testing.T) {
+	client := newTestClient(t, &fixture{items: itemsOf(newStory(1, 10, 2), newComment(2, 1))})
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		get     func() (uint, error)
+		want    uint
+		wantErr error
+	}{
+		{"story", func() (uint, error) {
+			story, err := hn.GetAs[hn.Story](ctx, client.Items, 1)
+			return story.ID, err
+		}, 1, nil},
+		{"comment", func() (uint, error) {
+			comment, err := hn.GetAs[hn.Comment](ctx, client.Items, 2)
+			return comment.ID, err
+		}, 2, nil},
+		{"mismatched type", func() (uint, error) {
+			story, err := hn.GetAs[hn.Story](ctx, client.Items, 2)
+			return story.ID, err
+		}, 0, nil},
+		{"missing", func() (uint, error) {
+			story, err := hn.GetAs[hn.Story](ctx, client.Items, 3)
+			return story.ID, err
+		}, 0, hn.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tt.get()
+
+			switch {
+			case tt.want != 0 && err != nil:
+				t.Fatalf("GetAs() error = %v", err)
+			case tt.want == 0 && err == nil:
+				t.Fatal("GetAs() error = nil, want an error")
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Errorf("GetAs() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if id != tt.want {
+				t.Errorf("GetAs() ID = %d, want %d", id, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnescaped(t *testing.T) {
+	story := newStory(1, 10)
+	story.Title = "Rust &amp; Go"
+	story.Text = "It&#x27;s &lt;fast&gt;"
+
+	client := newTestClient(t, &fixture{items: itemsOf(story)})
+	ctx := context.Background()
+
+	// All the methods returning items unescape the texts the same way.
+	tests := []struct {
+		name string
+		get  func() (hn.Item, error)
+	}{
+		{"Unescaped", func() (hn.Item, error) { return story.Unescaped(), nil }},
+		{"Unescaped twice", func() (hn.Item, error) { return story.Unescaped().Unescaped(), nil }},
+		{"Get", func() (hn.Item, error) { return client.Items.Get(ctx, 1) }},
+		{"GetRaw", func() (hn.Item, error) {
+			item, _, err := client.Items.GetRaw(ctx, 1)
+			return item, err
+		}},
+		{"List", func() (hn.Item, error) {
+			items, err := client.Items.List(ctx, []uint{1}, nil)
+			if err != nil {
+				return hn.Item{}, err
+			}
+
+			return items[0], nil
+		}},
+		{"Stream", func() (hn.Item, error) {
+			for item, err := range client.Items.Stream(ctx, []uint{1}, nil) {
+				return item, err
+			}
+
+			return hn.Item{}, hn.ErrNotFound
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := tt.get()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if item.Title != "Rust & Go" || item.Text != "It's <fast>" {
+				t.Errorf("Title, Text = %q, %q, want %q, %q", item.Title, item.Text, "Rust & Go", "It's <fast>")
+			}
+		})
+	}
+}
+
+func TestDescending(t *testing.T) {
+	// The item 4 is missing, so it's skipped.
+	f := &fixture{items: itemsOf(newStory(1, 1), newStory(2, 2), newStory(3, 3), newStory(5, 5), newStory(6, 6))}
+
+	tests := []struct {
+		name string
+		take int
+		want []uint
+	}{
+		{"first", 1, []uint{6}},
+		{"over missing item", 3, []uint{6, 5, 3}},
+		{"all", 10, []uint{6, 5, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, f)
+
+			var ids []uint
+
+			for item, err := range client.Live.Descending(context.Background()) {
+				if err != nil {
+					t.Fatalf("Descending() error = %v", err)
+				}
+
+				if ids = append(ids, item.ID); len(ids) == tt.take {
+					break
+				}
+			}
+
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("Descending() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescendingCanceled(t *testing.T) {
+	client := newTestClient(t, &fixture{items: itemsOf(newStory(1, 1), newStory(2, 2), newStory(3, 3))})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		ids  []uint
+		last error
+	)
+
+	for item, err := range client.Live.Descending(ctx) {
+		if err != nil {
+			last = err
+			continue
+		}
+
+		ids = append(ids, item.ID)
+		cancel()
+	}
+
+	if want := []uint{3}; !slices.Equal(ids, want) || !errors.Is(last, context.Canceled) {
+		t.Errorf("Descending() = %v, %v, want %v, context.Canceled", ids, last, want)
+	}
+}
+
+func TestUsersList(t *testing.T) {
+	f := &fixture{users: map[string]hn.User{
+		"pg":      {ID: "pg", Karma: 100},
+		"dang":    {ID: "dang", Karma: 200},
+		"tptacek": {ID: "tptacek", Karma: 300},
+	}}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name      string
+		usernames []string
+		want      []string
+		wantErr   error
+	}{
+		{"in order", []string{"tptacek", "pg", "dang"}, []string{"tptacek", "pg", "dang"}, nil},
+		{"duplicates", []string{"pg", "pg"}, []string{"pg", "pg"}, nil},
+		{"empty", nil, []string{}, nil},
+		{"missing user", []string{"pg", "nobody"}, nil, hn.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := client.Users.List(context.Background(), tt.usernames)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("List() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var got []string
+			if users != nil {
+				got = make([]string, 0, len(users))
+			}
+
+			for _, user := range users {
+				got = append(got, user.ID)
+			}
+
+			if !slices.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("List() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserSubmissionsLimit(t *testing.T) {
+	f := &fixture{
+		items: itemsOf(newStory(1, 1), newComment(2, 1), newStory(3, 3), newComment(4, 3), newStory(5, 5)),
+		users: map[string]hn.User{"pg": {ID: "pg", Submitted: idRange(1, 5)}},
+	}
+	client := newTestClient(t, f)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		get  func(limit int) ([]uint, error)
+	}{
+		{"items", func(limit int) ([]uint, error) {
+			items, err := client.Users.Items(ctx, "pg", nil, limit)
+			return hn.IDsOf(items), err
+		}},
+		{"stories", func(limit int) ([]uint, error) {
+			stories, err := client.Users.Stories(ctx, "pg", limit)
+			return hn.IDsOf(stories), err
+		}},
+		{"comments", func(limit int) ([]uint, error) {
+			comments, err := client.Users.Comments(ctx, "pg", limit)
+			return hn.IDsOf(comments), err
+		}},
+	}
+
+	// The limit applies to the submissions before they're filtered by type.
+	want := map[string]map[int][]uint{
+		"items":    {0: {1, 2, 3, 4, 5}, -1: {1, 2, 3, 4, 5}, 3: {1, 2, 3}, 10: {1, 2, 3, 4, 5}},
+		"stories":  {0: {1, 3, 5}, -1: {1, 3, 5}, 3: {1, 3}, 10: {1, 3, 5}},
+		"comments": {0: {2, 4}, -1: {2, 4}, 3: {2}, 10: {2, 4}},
+	}
+
+	for _, tt := range tests {
+		for _, limit := range []int{0, -1, 3, 10} {
+			t.Run(tt.name+"/"+strconv.Itoa(limit), func(t *testing.T) {
+				got, err := tt.get(limit)
+				if err != nil {
+					t.Fatalf("error = %v", err)
+				}
+
+				if !slices.Equal(got, want[tt.name][limit]) {
+					t.Errorf("got %v, want %v", got, want[tt.name][limit])
+				}
+			})
+		}
+	}
+}
+
+func TestItemError(t *testing.T) {
+	// The comment 3 of the story 1 is not JSON.
+	f := &fixture{items: itemsOf(newStory(1, 10, 2, 3), newComment(2, 1))}
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != "/item/3.json" {
+			return false
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html></html>"))
+
+		return true
+	}
+
+	client := newTestClient(t, f)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"List", func() error {
+			_, err := client.Items.List(ctx, idRange(1, 3), nil)
+			return err
+		}},
+		{"Thread", func() error {
+			_, err := client.Items.Thread(ctx, 1, 0)
+			return err
+		}},
+		{"GetWithKids", func() error {
+			_, _, err := client.Items.GetWithKids(ctx, 1, nil)
+			return err
+		}},
+		{"Stream", func() error {
+			for _, err := range client.Items.Stream(ctx, idRange(1, 3), nil) {
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+
+			var itemErr *hn.ItemError
+			if !errors.As(err, &itemErr) || itemErr.ID != 3 {
+				t.Fatalf("error = %v, want an *ItemError for item 3", err)
+			}
+
+			if !errors.Is(err, hn.ErrUnexpectedContentType) {
+				t.Errorf("error = %v, want it to wrap ErrUnexpectedContentType", err)
+			}
+
+			if want := "item 3: "; !strings.HasPrefix(err.Error(), want) {
+				t.Errorf("error = %q, want the prefix %q", err, want)
+			}
+		})
+	}
+}
+
+func TestGetRaw(t *testing.T) {
+	const body = `{"id":1,"type":"story","title":"A &amp; B","extra":{"field":true}}`
+
+	handler := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != "/item/1.json" {
+			return false
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+
+		return true
+	}
+
+	tests := []struct {
+		name  string
+		opts  []hn.Option
+		title string
+	}{
+		{"default", nil, "A & B"},
+		{"raw text", []hn.Option{hn.WithRawText()}, "A &amp; B"},
+		// GetRaw bypasses the item cache, so each call sends a request.
+		{"item cache", []hn.Option{hn.WithItemCache(10, 0)}, "A & B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{handler: handler}
+			client := newTestClient(t, f, tt.opts...)
+
+			for range 2 {
+				item, raw, err := client.Items.GetRaw(context.Background(), 1)
+				if err != nil {
+					t.Fatalf("GetRaw() error = %v", err)
+				}
+
+				if item.ID != 1 || item.Title != tt.title {
+					t.Errorf("GetRaw() item = %d %q, want 1 %q", item.ID, item.Title, tt.title)
+				}
+
+				// The raw JSON is returned as received, with the fields that Item doesn't have.
+				if string(raw) != body {
+					t.Errorf("GetRaw() raw = %s, want %s", raw, body)
+				}
+			}
+
+			if n := f.count("/item/1.json"); n != 2 {
+				t.Errorf("got %d requests, want 2", n)
+			}
+		})
+	}
+
+	client := newTestClient(t, &fixture{handler: handler})
+
+	if _, _, err := client.Items.GetRaw(context.Background(), 2); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("GetRaw(2) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListOrder(t *testing.T) {
+	f := topStories(5)
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		// The items with lower IDs are answered later, so the responses arrive in reverse order.
+		id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json"))
+		time.Sleep(time.Duration(5-id) * 5 * time.Millisecond)
+
+		return false
+	}
+
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name   string
+		ids    []uint
+		filter func(hn.Item) bool
+		want   []uint
+	}{
+		{"ascending", idRange(1, 5), nil, []uint{1, 2, 3, 4, 5}},
+		{"shuffled", []uint{3, 1, 5, 2, 4}, nil, []uint{3, 1, 5, 2, 4}},
+		{"filtered", []uint{5, 4, 3, 2, 1}, hn.MinScore(3), []uint{5, 4, 3}},
+		{"with missing", []uint{2, 9, 1}, nil, []uint{2, 1}},
+		{"duplicates", []uint{2, 1, 2}, nil, []uint{2, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := client.Items.List(context.Background(), tt.ids, tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if got := hn.IDsOf(items); !slices.Equal(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserSummary(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	day := 24 * time.Hour
+
+	user := func(karma int, age time.Duration, submitted ...uint) hn.User {
+		return hn.User{ID: "pg", Karma: karma, Created: hn.Timestamp{Time: now.Add(-age)}, Submitted: submitted}
+	}
+
+	tests := []struct {
+		name string
+		user hn.User
+		want hn.UserSummary
+	}{
+		{"old account", user(1000, 100*day, 1, 2, 3), hn.UserSummary{Karma: 1000, AccountAge: 100 * day, SubmissionCount: 3, KarmaPerDay: 10}},
+		{"no submissions", user(1, 4*day), hn.UserSummary{Karma: 1, AccountAge: 4 * day, KarmaPerDay: 0.25}},
+		{"younger than a day", user(12, time.Hour, 1), hn.UserSummary{Karma: 12, AccountAge: time.Hour, SubmissionCount: 1, KarmaPerDay: 12}},
+		{"negative karma", user(-10, 10*day), hn.UserSummary{Karma: -10, AccountAge: 10 * day, KarmaPerDay: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.Summary(now); got != tt.want {
+				t.Errorf("Summary() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("service", func(t *testing.T) {
+		f := &fixture{
+			items: itemsOf(newStory(1, 1), newStory(2, 1)),
+			users: map[string]hn.User{
+				"pg": {ID: "pg", Karma: 300, Created: hn.Timestamp{Time: time.Now().Add(-3 * day)}, Submitted: []uint{1, 2}},
+			},
+		}
+		client := newTestClient(t, f)
+
+		summary, err := client.Users.Summary(context.Background(), "pg")
+		if err != nil {
+			t.Fatalf("Summary() error = %v", err)
+		}
+
+		if summary.Karma != 300 || summary.SubmissionCount != 2 || summary.AccountAge < 3*day-time.Minute {
+			t.Errorf("Summary() = %+v, want 300 karma and 2 submissions of an account 3 days old", summary)
+		}
+
+		if n := f.total(); n != 1 {
+			t.Errorf("Summary() sent %d requests, want 1", n)
+		}
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		client := newTestClient(t, &fixture{})
+
+		if _, err := client.Users.Summary(context.Background(), "nobody"); !errors.Is(err, hn.ErrNotFound) {
+			t.Errorf("Summary() error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestWithStrictTypes(t *testing.T) {
+	f := &fixture{items: itemsOf(newStory(1, 10), newItem(2, "link"), newItem(3, ""), newItem(4, hn.PollOptionType))}
+
+	tests := []struct {
+		name    string
+		id      uint
+		opts    []hn.Option
+		wantErr error
+	}{
+		{"known type", 1, []hn.Option{hn.WithStrictTypes()}, nil},
+		{"poll option", 4, []hn.Option{hn.WithStrictTypes()}, nil},
+		{"unknown type", 2, []hn.Option{hn.WithStrictTypes()}, hn.ErrUnknownType},
+		{"empty type", 3, []hn.Option{hn.WithStrictTypes()}, hn.ErrUnknownType},
+		{"unknown type without the option", 2, nil, nil},
+		{"empty type without the option", 3, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, f, tt.opts...)
+
+			item, err := client.Items.Get(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get(%d) error = %v, want %v", tt.id, err, tt.wantErr)
+			}
+
+			if err == nil && item.ID != tt.id {
+				t.Errorf("Get(%d) = item %d", tt.id, item.ID)
+			}
+
+			_, err = client.Items.List(context.Background(), []uint{1, tt.id}, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("List() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecentSince(t *testing.T) {
+	small := itemsOf(newStory(1, 1), newStory(2, 1), newComment(3, 1), newStory(5, 1), newComment(6, 5))
+
+	large := make(map[uint]hn.Item)
+	for id := range hn.MaxRecentSince + 5 {
+		large[id+1] = newStory(id+1, 1)
+	}
+
+	tests := []struct {
+		name   string
+		items  map[uint]hn.Item
+		lastID uint
+		filter func(hn.Item) bool
+		want   []uint
+	}{
+		{"no new items", small, 6, nil, []uint{}},
+		{"last ID after the latest", small, 10, nil, []uint{}},
+		{"gap", small, 2, nil, []uint{3, 5, 6}},
+		{"filtered", small, 2, hn.ByType(hn.StoryType), []uint{5}},
+		{"from the start", small, 0, nil, []uint{1, 2, 3, 5, 6}},
+		{"bounded", large, 0, nil, idRange(1, hn.MaxRecentSince)},
+		{"rest of the range", large, hn.MaxRecentSince, nil, idRange(hn.MaxRecentSince+1, hn.MaxRecentSince+5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{items: tt.items}
+			client := newTestClient(t, f)
+
+			items, err := client.Live.RecentSince(context.Background(), tt.lastID, tt.filter)
+			if err != nil {
+				t.Fatalf("RecentSince(%d) error = %v", tt.lastID, err)
+			}
+
+			if got := hn.IDsOf(items); !slices.Equal(got, tt.want) || items == nil {
+				t.Errorf("RecentSince(%d) = %v, want %v", tt.lastID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	dead := newStory(2, 20)
+	dead.Dead = true
+
+	escaped := newStory(3, 30)
+	escaped.Title = "Go &amp; Rust"
+
+	f := &fixture{items: itemsOf(newStory(1, 10), dead, escaped)}
+
+	titles := func(items []hn.Item) []string {
+		list := make([]string, len(items))
+		for i, item := range items {
+			list[i] = item.Title
+		}
+
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		baseOpts  []hn.Option
+		cloneOpts []hn.Option
+		base      []string
+		clone     []string
+	}{
+		{"no options", nil, nil, []string{"Story 1", "Story 2", "Go & Rust"}, []string{"Story 1", "Story 2", "Go & Rust"}},
+		{"options kept", []hn.Option{hn.WithSkipDeadDeleted()}, nil, []string{"Story 1", "Go & Rust"}, []string{"Story 1", "Go & Rust"}},
+		{
+			"option added",
+			[]hn.Option{hn.WithSkipDeadDeleted()},
+			[]hn.Option{hn.WithRawText()},
+			[]string{"Story 1", "Go & Rust"},
+			[]string{"Story 1", "Go &amp; Rust"},
+		},
+		{
+			"option overridden",
+			[]hn.Option{hn.WithDefaultFilter(hn.MinScore(20))},
+			[]hn.Option{hn.WithDefaultFilter(nil)},
+			[]string{"Story 2", "Go & Rust"},
+			[]string{"Story 1", "Story 2", "Go & Rust"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestClient(t, f, tt.baseOpts...)
+			clone := base.Clone(tt.cloneOpts...)
+
+			for _, c := range []struct {
+				name   string
+				client *hn.Client
+				want   []string
+			}{
+				{"clone", clone, tt.clone},
+				{"base", base, tt.base},
+			} {
+				items, err := c.client.Items.List(context.Background(), idRange(1, 3), nil)
+				if err != nil {
+					t.Fatalf("%s: List() error = %v", c.name, err)
+				}
+
+				if got := titles(items); !slices.Equal(got, c.want) {
+					t.Errorf("%s: List() = %q, want %q", c.name, got, c.want)
+				}
+			}
+		})
+	}
+
+	t.Run("own cache", func(t *testing.T) {
+		f := &fixture{items: itemsOf(newStory(1, 10))}
+
+		base := newTestClient(t, f, hn.WithItemCache(10, 0))
+		clone := base.Clone()
+
+		for _, client := range []*hn.Client{base, base, clone, clone} {
+			if _, err := client.Items.Get(context.Background(), 1); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+		}
+
+		if n := f.count("/item/1.json"); n != 2 {
+			t.Errorf("item fetched %d times, want once by each client", n)
+		}
+	})
+}
+
+func TestListAs(t *testing.T) {
+	f := &fixture{items: itemsOf(
+		newStory(1, 10),
+		newComment(2, 1),
+		newStory(3, 30),
+		newItem(4, hn.JobType),
+		newComment(5, 3),
+	)}
+	client := newTestClient(t, f)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		list func(ids []uint, filter func(hn.Item) bool) ([]uint, error)
+		ids  []uint
+		keep func(hn.Item) bool
+		want []uint
+	}{
+		{"stories", func(ids []uint, filter func(hn.Item) bool) ([]uint, error) {
+			stories, err := hn.ListAs[hn.Story](ctx, client.Items, ids, filter)
+			return hn.IDsOf(stories), err
+		}, idRange(1, 5), nil, []uint{1, 3}},
+		{"filtered stories", func(ids []uint, filter func(hn.Item) bool) ([]uint, error) {
+			stories, err := hn.ListAs[hn.Story](ctx, client.Items, ids, filter)
+			return hn.IDsOf(stories), err
+		}, idRange(1, 5), hn.MinScore(20), []uint{3}},
+		{"comments", func(ids []uint, filter func(hn.Item) bool) ([]uint, error) {
+			comments, err := hn.ListAs[hn.Comment](ctx, client.Items, ids, filter)
+			return hn.IDsOf(comments), err
+		}, []uint{5, 4, 2}, nil, []uint{5, 2}},
+		{"no matching type", func(ids []uint, filter func(hn.Item) bool) ([]uint, error) {
+			polls, err := hn.ListAs[hn.Poll](ctx, client.Items, ids, filter)
+			return hn.IDsOf(polls), err
+		}, idRange(1, 5), nil, []uint{}},
+		{"filter sees only the type", func(ids []uint, filter func(hn.Item) bool) ([]uint, error) {
+			jobs, err := hn.ListAs[hn.Job](ctx, client.Items, ids, filter)
+			return hn.IDsOf(jobs), err
+		}, idRange(1, 5), func(item hn.Item) bool {
+			if item.Type != hn.JobType {
+				t.Errorf("filter called for a %s", item.Type)
+			}
+
+			return true
+		}, []uint{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.list(tt.ids, tt.keep)
+			if err != nil {
+				t.Fatalf("ListAs() error = %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ListAs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := hn.ListAs[hn.Story](ctx, client.Items, []uint{1, 6}, nil); err != nil {
+		t.Errorf("ListAs() with a missing item error = %v, want nil", err)
+	}
+}
+
+func TestTypedStories(t *testing.T) {
+	// The job 3 slips into the lists of stories.
+	f := &fixture{
+		items: itemsOf(newStory(1, 10), newStory(2, 20), newItem(3, hn.JobType), newStory(4, 40), newStory(5, 50)),
+		lists: map[string][]uint{
+			"newstories":  {5, 4, 3, 2, 1},
+			"topstories":  {4, 3, 1, 5},
+			"beststories": {2, 5},
+		},
+	}
+	client := newTestClient(t, f)
+	ctx := context.Background()
+
+	stories := map[string]func(limit uint) ([]hn.Story, error){
+		"new":  func(limit uint) ([]hn.Story, error) { return client.Live.NewStories(ctx, limit) },
+		"top":  func(limit uint) ([]hn.Story, error) { return client.Live.TopStories(ctx, limit) },
+		"best": func(limit uint) ([]hn.Story, error) { return client.Live.BestStories(ctx, limit) },
+	}
+
+	tests := []struct {
+		list  string
+		limit uint
+		want  []uint
+	}{
+		{"new", 0, []uint{5, 4, 2, 1}},
+		{"new", 3, []uint{5, 4}},
+		{"new", 10, []uint{5, 4, 2, 1}},
+		{"top", 0, []uint{4, 1, 5}},
+		{"top", 1, []uint{4}},
+		{"best", 0, []uint{2, 5}},
+		{"best", 1, []uint{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.list+"/"+strconv.Itoa(int(tt.limit)), func(t *testing.T) {
+			got, err := stories[tt.list](tt.limit)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if ids := hn.IDsOf(got); !slices.Equal(ids, tt.want) {
+				t.Errorf("stories = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestListMap(t *testing.T) {
+	f := &fixture{items: itemsOf(newStory(1, 10), newComment(2, 1), newStory(3, 30))}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name   string
+		ids    []uint
+		filter func(hn.Item) bool
+		want   []uint
+	}{
+		{"all items", []uint{3, 1, 2}, nil, []uint{1, 2, 3}},
+		{"filtered", []uint{1, 2, 3}, hn.ByType(hn.StoryType), []uint{1, 3}},
+		{"missing item", []uint{1, 4}, nil, []uint{1}},
+		{"duplicates", []uint{1, 1, 3}, nil, []uint{1, 3}},
+		{"empty", nil, nil, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := client.Items.ListMap(context.Background(), tt.ids, tt.filter)
+			if err != nil {
+				t.Fatalf("ListMap() error = %v", err)
+			}
+
+			if got := slices.Sorted(maps.Keys(m)); !slices.Equal(got, tt.want) {
+				t.Errorf("ListMap() keys = %v, want %v", got, tt.want)
+			}
+
+			for id, item := range m {
+				if item.ID != id {
+					t.Errorf("ListMap()[%d] = item %d", id, item.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestProfile(t *testing.T) {
+	user := hn.User{ID: "pg", Karma: 100, About: "Founder &amp; writer", Submitted: []uint{5, 4, 3, 2, 1}}
+
+	tests := []struct {
+		name   string
+		limit  int
+		filter func(hn.Item) bool
+		want   []uint
+	}{
+		{"all items", 0, nil, []uint{5, 4, 3, 2, 1}},
+		{"negative limit", -1, nil, []uint{5, 4, 3, 2, 1}},
+		{"limited", 2, nil, []uint{5, 4}},
+		{"filtered", 0, hn.ByType(hn.StoryType), []uint{5, 3, 1}},
+		{"limited and filtered", 3, hn.ByType(hn.CommentType), []uint{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{
+				items: itemsOf(newStory(1, 1), newComment(2, 1), newStory(3, 3), newComment(4, 3), newStory(5, 5)),
+				users: map[string]hn.User{"pg": user},
+			}
+			client := newTestClient(t, f)
+
+			got, items, err := client.Users.Profile(context.Background(), "pg", tt.limit, tt.filter)
+			if err != nil {
+				t.Fatalf("Profile() error = %v", err)
+			}
+
+			if got.ID != "pg" || got.Karma != 100 || got.About != "Founder & writer" || len(got.Submitted) != 5 {
+				t.Errorf("Profile() user = %+v, want the fields of pg", got)
+			}
+
+			if ids := hn.IDsOf(items); !slices.Equal(ids, tt.want) {
+				t.Errorf("Profile() items = %v, want %v", ids, tt.want)
+			}
+
+			if n := f.count("/user/pg.json"); n != 1 {
+				t.Errorf("user fetched %d times, want once", n)
+			}
+		})
+	}
+
+	client := newTestClient(t, &fixture{})
+	if _, _, err := client.Users.Profile(context.Background(), "nobody", 0, nil); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("Profile() of a missing user error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestExists(t *testing.T) {
+	deleted := newComment(2, 1)
+	deleted.Deleted = true
+
+	dead := newComment(3, 1)
+	dead.Dead = true
+
+	f := &fixture{
+		items: itemsOf(newStory(1, 10), deleted, dead),
+		handler: func(w http.ResponseWriter, r *http.Request) bool {
+			if r.URL.Path != "/item/5.json" {
+				return false
+			}
+
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return true
+		},
+	}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name    string
+		id      uint
+		want    bool
+		wantErr bool
+	}{
+		{"existing", 1, true, false},
+		{"deleted", 2, false, false},
+		{"dead", 3, true, false},
+		{"not found", 4, false, false},
+		{"error", 5, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Items.Exists(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exists(%d) error = %v, want error: %v", tt.id, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Exists(%d) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserTopStories(t *testing.T) {
+	f := &fixture{
+		items: itemsOf(
+			newStory(1, 10),
+			newComment(2, 1),
+			newStory(3, 50),
+			newStory(4, 30),
+			newItem(5, hn.JobType),
+			newStory(6, 30),
+			newStory(7, 5),
+		),
+		users: map[string]hn.User{"pg": {ID: "pg", Submitted: []uint{7, 6, 5, 4, 3, 2, 1}}},
+	}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name string
+		n    int
+		want []uint
+	}{
+		{"top one", 1, []uint{3}},
+		{"top three with a tie", 3, []uint{3, 6, 4}},
+		{"more than stories", 10, []uint{3, 6, 4, 1, 7}},
+		{"all", 0, []uint{3, 6, 4, 1, 7}},
+		{"negative", -1, []uint{3, 6, 4, 1, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stories, err := client.Users.TopStories(context.Background(), "pg", tt.n)
+			if err != nil {
+				t.Fatalf("TopStories() error = %v", err)
+			}
+
+			if got := hn.IDsOf(stories); !slices.Equal(got, tt.want) {
+				t.Errorf("TopStories(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := client.Users.TopStories(context.Background(), "nobody", 1); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("TopStories() of a missing user error = %v, want ErrNotFound", err)
+	}
+}
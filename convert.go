@@ -2,23 +2,23 @@ package hn
 
 import "fmt"
 
-var converters = map[string]func(Item) Convertible{
-	StoryType: func(i Item) Convertible {
+var converters = map[ItemKind]func(Item) Convertible{
+	KindStory: func(i Item) Convertible {
 		return ToStory(i)
 	},
-	CommentType: func(i Item) Convertible {
+	KindComment: func(i Item) Convertible {
 		return ToComment(i)
 	},
-	AskType: func(i Item) Convertible {
+	KindAsk: func(i Item) Convertible {
 		return ToAsk(i)
 	},
-	JobType: func(i Item) Convertible {
+	KindJob: func(i Item) Convertible {
 		return ToJob(i)
 	},
-	PollType: func(i Item) Convertible {
+	KindPoll: func(i Item) Convertible {
 		return ToPoll(i)
 	},
-	PollOptionType: func(i Item) Convertible {
+	KindPollOption: func(i Item) Convertible {
 		return ToPollOption(i)
 	},
 }
@@ -37,7 +37,7 @@ func To[C Convertible](item Item) (c C, err error) {
 		return c, fmt.Errorf("mismatched types. expected '%v', but got '%v'", c.Type(), item.Type)
 	}
 
-	fn, ok := converters[item.Type]
+	fn, ok := converters[item.Kind()]
 	if !ok {
 		return c, fmt.Errorf("unsupported type: %v", item.Type)
 	}
@@ -65,6 +65,41 @@ func ToList[C Convertible](items []Item) []C {
 	return list
 }
 
+// Grouped contains items grouped by their type.
+type Grouped struct {
+	Stories     []Story
+	Comments    []Comment
+	Asks        []Ask
+	Jobs        []Job
+	Polls       []Poll
+	PollOptions []PollOption
+}
+
+// Group converts a slice of items to structs of specific types, grouping them by type in a single pass.
+// Items of unsupported types are excluded.
+func Group(items []Item) Grouped {
+	var g Grouped
+
+	for _, item := range items {
+		switch item.Kind() {
+		case KindStory:
+			g.Stories = append(g.Stories, ToStory(item))
+		case KindComment:
+			g.Comments = append(g.Comments, ToComment(item))
+		case KindAsk:
+			g.Asks = append(g.Asks, ToAsk(item))
+		case KindJob:
+			g.Jobs = append(g.Jobs, ToJob(item))
+		case KindPoll:
+			g.Polls = append(g.Polls, ToPoll(item))
+		case KindPollOption:
+			g.PollOptions = append(g.PollOptions, ToPollOption(item))
+		}
+	}
+
+	return g
+}
+
 // ToComment converts an Item struct to a Comment struct.
 func ToComment(item Item) Comment {
 	return Comment{
@@ -128,3 +163,99 @@ func ToPollOption(item Item) PollOption {
 		Text:     item.Text,
 	}
 }
+
+// FromConvertible converts a struct of a specific type (Comment, Story, Ask, Job, Poll or PollOption)
+// back to an Item struct. It's the inverse of To, so the fields that the specific type doesn't have are left empty.
+func FromConvertible[C Convertible](c C) Item {
+	switch v := any(c).(type) {
+	case Comment:
+		return FromComment(v)
+	case Story:
+		return FromStory(v)
+	case Ask:
+		return FromAsk(v)
+	case Job:
+		return FromJob(v)
+	case Poll:
+		return FromPoll(v)
+	case PollOption:
+		return FromPollOption(v)
+	default:
+		return Item{baseItem: baseItem{Type: c.Type()}}
+	}
+}
+
+// FromComment converts a Comment struct to an Item struct.
+func FromComment(c Comment) Item {
+	return Item{
+		baseItem: c.baseItem,
+		Kids:     c.Kids,
+		Parent:   c.Parent,
+		Text:     c.Text,
+	}
+}
+
+// FromStory converts a Story struct to an Item struct.
+func FromStory(s Story) Item {
+	return Item{
+		baseItem:    s.baseItem,
+		Descendants: s.Descendants,
+		Kids:        s.Kids,
+		Text:        s.Text,
+		Title:       s.Title,
+		URL:         s.URL,
+	}
+}
+
+// FromAsk converts an Ask struct to an Item struct.
+func FromAsk(a Ask) Item {
+	return Item{
+		baseItem:    a.baseItem,
+		Descendants: a.Descendants,
+		Kids:        a.Kids,
+		Text:        a.Text,
+		Title:       a.Title,
+	}
+}
+
+// FromJob converts a Job struct to an Item struct.
+func FromJob(j Job) Item {
+	return Item{
+		baseItem: j.baseItem,
+		Text:     j.Text,
+		Title:    j.Title,
+		URL:      j.URL,
+	}
+}
+
+// FromPoll converts a Poll struct to an Item struct.
+func FromPoll(p Poll) Item {
+	return Item{
+		baseItem:    p.baseItem,
+		Descendants: p.Descendants,
+		Kids:        p.Kids,
+		Parts:       p.Parts,
+		Text:        p.Text,
+		Title:       p.Title,
+	}
+}
+
+// FromPollOption converts a PollOption struct to an Item struct.
+func FromPollOption(o PollOption) Item {
+	return Item{
+		baseItem: o.baseItem,
+		Poll:     o.Poll,
+		Text:     o.Text,
+	}
+}
+
+// Map returns the results of calling fn on each of the items (e.g., the titles of stories), in the same order.
+func Map[S Sortable, T any](items []S, fn func(S) T) []T {
+	list := make([]T, 0, len(items))
+
+	for _, item := range items {
+		list = append(list, fn(item))
+	}
+
+	return list
+}
@@ -0,0 +1,157 @@
+package hn_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestGroup(t *testing.T) {
+	items := []hn.Item{
+		newStory(1, 10),
+		newComment(2, 1),
+		newItem(3, hn.AskType),
+		newItem(4, hn.JobType),
+		newStory(5, 20),
+		newItem(6, hn.PollType),
+		newItem(7, hn.PollOptionType),
+		newComment(8, 2),
+		newItem(9, "unknown"),
+	}
+
+	g := hn.Group(items)
+
+	tests := []struct {
+		name string
+		got  []uint
+		want []uint
+	}{
+		{"stories", hn.IDsOf(g.Stories), []uint{1, 5}},
+		{"comments", hn.IDsOf(g.Comments), []uint{2, 8}},
+		{"asks", hn.IDsOf(g.Asks), []uint{3}},
+		{"jobs", hn.IDsOf(g.Jobs), []uint{4}},
+		{"polls", hn.IDsOf(g.Polls), []uint{6}},
+		{"poll options", hn.IDsOf(g.PollOptions), []uint{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("Group() %s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+
+	if g := hn.Group(nil); g.Stories != nil || g.Comments != nil || g.PollOptions != nil {
+		t.Errorf("Group(nil) = %+v, want no items", g)
+	}
+
+	if story := g.Stories[1]; story.Score != 20 || story.Title != "Story 5" {
+		t.Errorf("Group() story = %+v, want the fields of item 5", story)
+	}
+}
+
+func TestFromConvertible(t *testing.T) {
+	base := func(id uint, itemType string) hn.Item {
+		item := newItem(id, itemType)
+		item.By, item.Score, item.Dead = "pg", 10, true
+		item.Time = hn.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+		return item
+	}
+
+	story := base(1, hn.StoryType)
+	story.Title, story.URL, story.Text, story.Descendants, story.Kids = "Story", "https://example.com", "text", 2, []uint{2, 3}
+
+	comment := base(2, hn.CommentType)
+	comment.Parent, comment.Text, comment.Kids = 1, "Comment", []uint{3}
+
+	ask := base(3, hn.AskType)
+	ask.Title, ask.Text, ask.Descendants, ask.Kids = "Ask HN", "question", 1, []uint{4}
+
+	job := base(4, hn.JobType)
+	job.Title, job.URL, job.Text = "Job", "https://example.com/jobs", "hiring"
+
+	poll := base(5, hn.PollType)
+	poll.Title, poll.Text, poll.Parts, poll.Kids, poll.Descendants = "Poll", "vote", []uint{6}, []uint{7}, 1
+
+	option := base(6, hn.PollOptionType)
+	option.Poll, option.Text = 5, "yes"
+
+	tests := []struct {
+		name  string
+		item  hn.Item
+		round func(hn.Item) (hn.Item, hn.Item)
+	}{
+		{"story", story, func(i hn.Item) (hn.Item, hn.Item) {
+			return hn.FromStory(hn.ToStory(i)), hn.FromConvertible(hn.ToStory(i))
+		}},
+		{"comment", comment, func(i hn.Item) (hn.Item, hn.Item) {
+			return hn.FromComment(hn.ToComment(i)), hn.FromConvertible(hn.ToComment(i))
+		}},
+		{"ask", ask, func(i hn.Item) (hn.Item, hn.Item) {
+			return hn.FromAsk(hn.ToAsk(i)), hn.FromConvertible(hn.ToAsk(i))
+		}},
+		{"job", job, func(i hn.Item) (hn.Item, hn.Item) {
+			return hn.FromJob(hn.ToJob(i)), hn.FromConvertible(hn.ToJob(i))
+		}},
+		{"poll", poll, func(i hn.Item) (hn.Item, hn.Item) {
+			return hn.FromPoll(hn.ToPoll(i)), hn.FromConvertible(hn.ToPoll(i))
+		}},
+		{"poll option", option, func(i hn.Item) (hn.Item, hn.Item) {
+			return hn.FromPollOption(hn.ToPollOption(i)), hn.FromConvertible(hn.ToPollOption(i))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, generic := tt.round(tt.item)
+
+			if !reflect.DeepEqual(got, tt.item) {
+				t.Errorf("From(To(item)) = %+v, want %+v", got, tt.item)
+			}
+
+			if !reflect.DeepEqual(generic, tt.item) {
+				t.Errorf("FromConvertible(To(item)) = %+v, want %+v", generic, tt.item)
+			}
+		})
+	}
+
+	// The fields that the specific type doesn't have are left empty.
+	if got := hn.FromComment(hn.ToComment(story)); got.Title != "" || got.URL != "" || got.Descendants != 0 {
+		t.Errorf("FromComment(ToComment(story)) = %+v, want no story fields", got)
+	}
+}
+
+func TestMap(t *testing.T) {
+	stories := hn.ToList[hn.Story]([]hn.Item{newStory(1, 10), newStory(2, 20), newStory(3, 30)})
+	comments := hn.ToList[hn.Comment]([]hn.Item{newComment(4, 1), newComment(5, 4)})
+
+	type link struct {
+		id    uint
+		title string
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"titles", hn.Map(stories, func(s hn.Story) string { return s.Title }), []string{"Story 1", "Story 2", "Story 3"}},
+		{"scores", hn.Map(stories, func(s hn.Story) int { return s.Score }), []int{10, 20, 30}},
+		{"pairs", hn.Map(stories[:2], func(s hn.Story) link { return link{s.ID, s.Title} }), []link{{1, "Story 1"}, {2, "Story 2"}}},
+		{"comments", hn.Map(comments, func(c hn.Comment) uint { return c.Parent }), []uint{1, 4}},
+		{"empty", hn.Map([]hn.Story{}, func(s hn.Story) string { return s.Title }), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("Map() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package hn_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestWriteCSV(t *testing.T) {
+	story := newStory(1, 10)
+	story.By, story.URL, story.Descendants = "pg", "https://example.com", 3
+	story.Title = `Say "hello", world`
+	story.Time = hn.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	comment := newComment(2, 1)
+	comment.By = "dang"
+
+	tests := []struct {
+		name    string
+		columns []string
+		items   []hn.Item
+		want    string
+	}{
+		{
+			"all columns",
+			[]string{"id", "by", "score", "time", "type", "title", "url", "descendants"},
+			[]hn.Item{story, comment},
+			"id,by,score,time,type,title,url,descendants\n" +
+				`1,pg,10,2024-05-01T12:00:00Z,story,"Say ""hello"", world",https://example.com,3` + "\n" +
+				"2,dang,0,,comment,,,0\n",
+		},
+		{"some columns", []string{"title", "id"}, []hn.Item{story}, "title,id\n\"Say \"\"hello\"\", world\",1\n"},
+		{"header only", []string{"id"}, nil, "id\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := hn.WriteCSV(&buf, tt.items, tt.columns); err != nil {
+				t.Fatalf("WriteCSV() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSVError(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := hn.WriteCSV(&buf, []hn.Item{newStory(1, 1)}, []string{"id", "kids"})
+	if err == nil || !strings.Contains(err.Error(), `"kids"`) {
+		t.Errorf("WriteCSV() error = %v, want an error about the unknown column", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("WriteCSV() wrote %q, want nothing", buf.String())
+	}
+
+	if err := hn.WriteCSV(failingWriter{}, []hn.Item{newStory(1, 1)}, []string{"id"}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("WriteCSV() error = %v, want the error of the writer", err)
+	}
+}
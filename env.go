@@ -0,0 +1,73 @@
+package hn
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// NewClientFromEnv returns a new client (see NewClient) configured with the environment variables:
+//
+//   - HN_BASE_URL sets the base URL of the API (see WithBaseURL)
+//   - HN_USER_AGENT sets the User-Agent header of the requests (see WithUserAgent)
+//   - HN_MAX_WORKERS sets the worker limit, e.g., "10", or "-1" for no limit (see WithMaxWorkers)
+//   - HN_TIMEOUT sets the request timeout, e.g., "10s" (see WithTimeout)
+//   - HN_RATE_LIMIT sets the maximum number of requests per second, e.g., "5" (see WithRateLimit)
+//
+// The variables that are not set or empty keep their default values, and opts are applied after them.
+// It returns an error if any of the variables has an invalid value. The values must be positive,
+// except for the "-1" of HN_MAX_WORKERS; to keep the default, leave the variable unset.
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	var env []Option
+
+	if v := os.Getenv("HN_BASE_URL"); v != "" {
+		env = append(env, WithBaseURL(v))
+	}
+
+	if v := os.Getenv("HN_USER_AGENT"); v != "" {
+		env = append(env, WithUserAgent(v))
+	}
+
+	if v := os.Getenv("HN_MAX_WORKERS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse HN_MAX_WORKERS: %w", err)
+		}
+
+		if n <= 0 && n != -1 {
+			return nil, errors.New("invalid HN_MAX_WORKERS: the worker limit must be positive, or -1 for no limit")
+		}
+
+		env = append(env, WithMaxWorkers(n))
+	}
+
+	if v := os.Getenv("HN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse HN_TIMEOUT: %w", err)
+		}
+
+		if d <= 0 {
+			return nil, errors.New("invalid HN_TIMEOUT: the timeout must be positive")
+		}
+
+		env = append(env, WithTimeout(d))
+	}
+
+	if v := os.Getenv("HN_RATE_LIMIT"); v != "" {
+		limit, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse HN_RATE_LIMIT: %w", err)
+		}
+
+		if !(limit > 0) {
+			return nil, errors.New("invalid HN_RATE_LIMIT: the rate limit must be positive")
+		}
+
+		env = append(env, WithRateLimit(limit))
+	}
+
+	return NewClient(nil, append(env, opts...)...), nil
+}
@@ -0,0 +1,73 @@
+package hn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewClientFromEnvOptions(t *testing.T) {
+	ClearEnv(t)
+
+	t.Setenv("HN_BASE_URL", "http://localhost:8080/v0/")
+	t.Setenv("HN_MAX_WORKERS", "7")
+	t.Setenv("HN_TIMEOUT", "5s")
+	t.Setenv("HN_RATE_LIMIT", "50")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+
+	o := client.Items.opts
+
+	if o.baseURL != "http://localhost:8080/v0" {
+		t.Errorf("baseURL = %q, want %q", o.baseURL, "http://localhost:8080/v0")
+	}
+
+	if n := o.workerLimit(); n != 7 {
+		t.Errorf("workerLimit() = %d, want 7", n)
+	}
+
+	if o.timeout == nil || *o.timeout != 5*time.Second {
+		t.Errorf("timeout = %v, want 5s", o.timeout)
+	}
+
+	if o.limiter == nil {
+		t.Error("limiter is nil, want a rate limiter")
+	}
+}
+
+func TestNewClientFromEnvDefaults(t *testing.T) {
+	ClearEnv(t)
+
+	client, err := NewClientFromEnv(WithMaxWorkers(3))
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+
+	o := client.Items.opts
+
+	if o.baseURL != "" || o.userAgent != "" || o.timeout != nil || o.limiter != nil {
+		t.Errorf("options = %+v, want defaults", o)
+	}
+
+	// The options passed to NewClientFromEnv are applied after the environment variables.
+	if n := o.workerLimit(); n != 3 {
+		t.Errorf("workerLimit() = %d, want 3", n)
+	}
+}
+
+func TestNewClientFromEnvNoWorkerLimit(t *testing.T) {
+	ClearEnv(t)
+
+	t.Setenv("HN_MAX_WORKERS", "-1")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+
+	if n := client.Items.opts.workerLimit(); n != -1 {
+		t.Errorf("workerLimit() = %d, want -1", n)
+	}
+}
@@ -0,0 +1,71 @@
+package hn_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	hn.ClearEnv(t)
+
+	var userAgent string
+
+	f := &fixture{
+		items: itemsOf(newStory(1, 10)),
+		handler: func(w http.ResponseWriter, r *http.Request) bool {
+			userAgent = r.UserAgent()
+			return false
+		},
+	}
+
+	server := httptest.NewServer(f)
+	defer server.Close()
+
+	t.Setenv("HN_BASE_URL", server.URL)
+	t.Setenv("HN_USER_AGENT", "hn-test/1.0")
+
+	client, err := hn.NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+
+	if _, err := client.Items.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if userAgent != "hn-test/1.0" {
+		t.Errorf("User-Agent = %q, want %q", userAgent, "hn-test/1.0")
+	}
+}
+
+func TestNewClientFromEnvInvalid(t *testing.T) {
+	tests := []struct {
+		key, value string
+	}{
+		{"HN_MAX_WORKERS", "ten"},
+		{"HN_MAX_WORKERS", "0"},
+		{"HN_MAX_WORKERS", "-5"},
+		{"HN_TIMEOUT", "10"},
+		{"HN_TIMEOUT", "0s"},
+		{"HN_TIMEOUT", "-1s"},
+		{"HN_RATE_LIMIT", "fast"},
+		{"HN_RATE_LIMIT", "0"},
+		{"HN_RATE_LIMIT", "-2"},
+		{"HN_RATE_LIMIT", "NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			hn.ClearEnv(t)
+			t.Setenv(tt.key, tt.value)
+
+			if client, err := hn.NewClientFromEnv(); err == nil {
+				t.Errorf("NewClientFromEnv() = %v, want an error", client)
+			}
+		})
+	}
+}
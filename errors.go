@@ -0,0 +1,39 @@
+package hn
+
+import "fmt"
+
+// ItemError is an error of fetching the item with a specific ID
+// as part of an operation over multiple items (e.g., ItemService.List).
+type ItemError struct {
+	ID  uint
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.ID, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// maxDecodeErrorBody is the maximum number of bytes of the response body kept in a DecodeError.
+const maxDecodeErrorBody = 1024
+
+// DecodeError is an error of decoding the JSON response from a specific URL
+// (e.g., an HTML error page returned with a 200 status code). If the response is not JSON
+// according to its Content-Type header, Err wraps ErrUnexpectedContentType.
+// Body contains the beginning of the response body, up to 1 KiB.
+type DecodeError struct {
+	URL  string
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode response JSON from %s: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
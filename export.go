@@ -0,0 +1,121 @@
+package hn
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"time"
+)
+
+// WriteNDJSON writes the items to w as newline-delimited JSON, one item per line.
+// Each item is written to w as soon as it's encoded, so the items are not buffered in memory.
+func WriteNDJSON(w io.Writer, items []Item) error {
+	enc := json.NewEncoder(w)
+
+	for _, item := range items {
+		err := enc.Encode(item)
+		if err != nil {
+			return fmt.Errorf("write item %d: %w", item.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadNDJSON returns an iterator over the items read from r as newline-delimited JSON,
+// in the format written by WriteNDJSON. The iteration stops after the first error.
+func ReadNDJSON(r io.Reader) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		dec := json.NewDecoder(r)
+
+		for {
+			var item Item
+
+			err := dec.Decode(&item)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				yield(Item{}, fmt.Errorf("read item: %w", err))
+				return
+			}
+
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// csvColumns contains the columns supported by WriteCSV, with the functions formatting their values.
+var csvColumns = map[string]func(Item) string{
+	"id":          func(i Item) string { return strconv.FormatUint(uint64(i.ID), 10) },
+	"by":          func(i Item) string { return i.By },
+	"score":       func(i Item) string { return strconv.Itoa(i.Score) },
+	"time":        formatCSVTime,
+	"type":        func(i Item) string { return i.Type },
+	"title":       func(i Item) string { return i.Title },
+	"url":         func(i Item) string { return i.URL },
+	"descendants": func(i Item) string { return strconv.Itoa(i.Descendants) },
+}
+
+// formatCSVTime formats the time of the item as RFC 3339, or as an empty string for the zero time.
+func formatCSVTime(i Item) string {
+	if i.Time.IsZero() {
+		return ""
+	}
+
+	return i.Time.Format(time.RFC3339)
+}
+
+// WriteCSV writes the items to w as CSV: a header row with the names of the columns,
+// followed by a row with the values of the columns for each item. The supported columns are
+// "id", "by", "score", "time" (formatted as RFC 3339), "type", "title", "url" and "descendants".
+// It returns an error if any of the columns is not supported, without writing anything.
+// The rows are flushed to w periodically, so large slices of items are not buffered in memory.
+func WriteCSV(w io.Writer, items []Item, columns []string) error {
+	formats := make([]func(Item) string, len(columns))
+
+	for i, column := range columns {
+		format, ok := csvColumns[column]
+		if !ok {
+			return fmt.Errorf("unknown CSV column: %q", column)
+		}
+
+		formats[i] = format
+	}
+
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(columns)
+	if err != nil {
+		return fmt.Errorf("write CSV header: %w", err)
+	}
+
+	row := make([]string, len(columns))
+
+	for _, item := range items {
+		for i, format := range formats {
+			row[i] = format(item)
+		}
+
+		// The writer is buffered and flushes its buffer to w when it's full.
+		err := cw.Write(row)
+		if err != nil {
+			return fmt.Errorf("write item %d: %w", item.ID, err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("write CSV: %w", err)
+	}
+
+	return nil
+}
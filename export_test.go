@@ -0,0 +1,21 @@
+package hn
+
+import "testing"
+
+// The identifiers below are exported only for the tests of the package hn_test.
+
+const MaxDecodeErrorBody = maxDecodeErrorBody
+
+var MaxRecentSince = maxRecentSince
+
+// IDsOf returns the IDs of the items in their order.
+func IDsOf[S Sortable](items []S) []uint {
+	return Map(items, S.getID)
+}
+
+// ClearEnv unsets the environment variables read by NewClientFromEnv for the duration of the test.
+func ClearEnv(t *testing.T) {
+	for _, key := range []string{"HN_BASE_URL", "HN_USER_AGENT", "HN_MAX_WORKERS", "HN_TIMEOUT", "HN_RATE_LIMIT"} {
+		t.Setenv(key, "")
+	}
+}
@@ -0,0 +1,307 @@
+package hn
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fetch sends an HTTP request to the Hacker News API and returns a value of the specified type.
+func Fetch[T any](ctx context.Context, client *http.Client, method, url string) (T, error) {
+	return fetch[T](ctx, client, newOptions(), method, url)
+}
+
+// Do sends the HTTP request and returns the JSON response decoded into a value of the specified type.
+// Unlike Fetch, the request can be sent to any URL, with any method and body.
+// It returns ErrNotFound if the response is null.
+func Do[T any](client *http.Client, req *http.Request) (T, error) {
+	return doValue[T](client, newOptions(), req)
+}
+
+// fetch is like Fetch, but uses the settings of the client options (e.g., the base URL).
+func fetch[T any](ctx context.Context, client *http.Client, opts *options, method, url string) (T, error) {
+	req, err := http.NewRequestWithContext(ctx, method, (cmp.Or(opts.baseURL, baseURL) + url + ".json"), nil)
+	if err != nil {
+		var t T
+		return t, fmt.Errorf("create HTTP request: %w", err)
+	}
+
+	return doValue[T](client, opts, req)
+}
+
+// doValue is like Do, but uses the settings of the client options (e.g., the request hook).
+func doValue[T any](client *http.Client, opts *options, req *http.Request) (T, error) {
+	var t T
+
+	// The API returns null for missing values, which leaves the pointer nil.
+	var value *T
+
+	err := do(client, opts, req, &value)
+	if err != nil {
+		return t, err
+	}
+
+	if value == nil {
+		return t, ErrNotFound
+	}
+
+	return *value, nil
+}
+
+// do sends the HTTP request and decodes the JSON response into out, which must be a pointer.
+// The User-Agent header is set unless the request already has one.
+//
+// If retries are enabled, the request is sent again after a backoff delay when it fails
+// with a network error or a retryable status code (429 or 5xx). A request with a body
+// that can't be sent again (i.e., without GetBody) is never retried.
+func do(client *http.Client, opts *options, req *http.Request, out any) error {
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", cmp.Or(opts.userAgent, userAgent))
+	}
+
+	for attempt := 0; ; attempt++ {
+		retry, err := send(client, opts, req, out, attempt)
+		if err == nil {
+			opts.retryBudget.deposit()
+			return nil
+		}
+
+		if !retry || attempt+1 >= opts.retryAttempts || !rewindable(req) || !opts.retryBudget.withdraw() {
+			return err
+		}
+
+		timer := time.NewTimer(opts.backoff.delay(attempt))
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", req.Context().Err(), err)
+		}
+	}
+}
+
+// rewindable reports whether the body of the request, if any, can be read again for a retry.
+func rewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// send makes a single attempt of sending the request, reporting whether the attempt can be retried on failure.
+// The request hook, if any, is called after the response has been decoded.
+func send(client *http.Client, opts *options, req *http.Request, out any, attempt int) (retry bool, err error) {
+	info := RequestInfo{Method: req.Method, URL: req.URL.String(), Attempt: attempt}
+
+	// start is set when the request is sent, after waiting for the rate and concurrency limits.
+	var start time.Time
+
+	if opts.requestHook != nil {
+		defer func() {
+			if !start.IsZero() {
+				info.Elapsed = time.Since(start)
+			}
+
+			info.Err = err
+			opts.requestHook(info)
+		}()
+	}
+
+	parent := req.Context()
+	ctx := parent
+
+	err = opts.limiter.wait(parent)
+	if err != nil {
+		return false, fmt.Errorf("wait for rate limit: %w", err)
+	}
+
+	if opts.sem != nil {
+		err = opts.sem.Acquire(parent, 1)
+		if err != nil {
+			return false, fmt.Errorf("wait for concurrency limit: %w", err)
+		}
+		defer opts.sem.Release(1)
+	}
+
+	if opts.requestTimeout > 0 {
+		// The deadline of the request context still applies if it's earlier.
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.requestTimeout)
+		defer cancel()
+	}
+
+	req = req.Clone(ctx)
+
+	if attempt > 0 && req.GetBody != nil {
+		req.Body, err = req.GetBody()
+		if err != nil {
+			return false, fmt.Errorf("create HTTP request: %w", err)
+		}
+	}
+
+	cached, conditional := opts.conditional.get(req)
+	if conditional {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	start = time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Network errors and per-request timeouts can be retried, unless the caller canceled the request.
+		return parent.Err() == nil, fmt.Errorf("send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	info.StatusCode = resp.StatusCode
+
+	body := &countingReader{r: resp.Body}
+	if opts.maxBodyBytes > 0 {
+		body.r = &limitedReader{r: resp.Body, n: opts.maxBodyBytes}
+	}
+
+	defer func() {
+		info.Bytes = body.n
+	}()
+
+	if resp.StatusCode == http.StatusNotModified && conditional {
+		return false, decode(info.URL, bytes.NewReader(cached.body), out)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if ct := resp.Header.Get("Content-Type"); !isJSON(ct) {
+		data, _ := io.ReadAll(io.LimitReader(body, maxDecodeErrorBody))
+
+		return false, &DecodeError{URL: info.URL, Body: data, Err: fmt.Errorf("%w: %s", ErrUnexpectedContentType, ct)}
+	}
+
+	if !opts.conditional.cacheable(req, resp) {
+		return false, decode(info.URL, body, out)
+	}
+
+	// The cacheable responses are read in full, so the body can be stored for the conditional requests.
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return false, fmt.Errorf("read response JSON: %w", err)
+	}
+
+	err = decode(info.URL, bytes.NewReader(data), out)
+	if err != nil {
+		return false, err
+	}
+
+	opts.conditional.add(req, resp, data)
+
+	return false, nil
+}
+
+// decode decodes the JSON value of the response from url, read from r, into out, which must be a pointer.
+// If the response is not valid JSON, it returns a *DecodeError with the beginning of the body.
+func decode(url string, r io.Reader, out any) error {
+	prefix := &prefixReader{r: r}
+
+	err := json.NewDecoder(prefix).Decode(out)
+	if err != nil {
+		if prefix.err != nil && prefix.err != io.EOF {
+			return fmt.Errorf("read response JSON: %w", err)
+		}
+
+		// The decoder may stop reading early, so the rest of the beginning of the body is read for the error.
+		_, _ = io.Copy(io.Discard, io.LimitReader(prefix, int64(maxDecodeErrorBody-len(prefix.buf))))
+
+		return &DecodeError{URL: url, Body: prefix.buf, Err: err}
+	}
+
+	return nil
+}
+
+// isJSON reports whether the content type of a response is JSON (e.g., "application/json; charset=utf-8").
+// A missing content type is accepted, since the JSON can still be decoded.
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// countingReader counts the number of bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// limitedReader reads from the underlying reader until n bytes are left,
+// and then fails with ErrResponseTooLarge if there are more bytes to read.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// Check whether the reader is at the end, so a body of exactly n bytes is accepted.
+		n, err := l.r.Read(make([]byte, 1))
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+
+	return n, err
+}
+
+// prefixReader keeps the first maxDecodeErrorBody bytes read from the underlying reader
+// and the last error returned by it.
+type prefixReader struct {
+	r   io.Reader
+	buf []byte
+	err error
+}
+
+func (p *prefixReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if left := maxDecodeErrorBody - len(p.buf); left > 0 {
+		p.buf = append(p.buf, b[:min(n, left)]...)
+	}
+
+	p.err = err
+
+	return n, err
+}
@@ -0,0 +1,77 @@
+package hn
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeKeepsOnlyPrefix(t *testing.T) {
+	body := bytes.Repeat([]byte("["), 10*maxDecodeErrorBody)
+
+	var out []any
+
+	err := decode("url", bytes.NewReader(body), &out)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("decode() error = %v, want a *DecodeError", err)
+	}
+
+	if len(decodeErr.Body) != maxDecodeErrorBody {
+		t.Errorf("got %d bytes of the body, want %d", len(decodeErr.Body), maxDecodeErrorBody)
+	}
+}
+
+func TestDoRetriesBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     func() io.Reader
+		requests int
+	}{
+		// http.NewRequest sets GetBody for the bytes.Reader, so the body is sent again on each retry.
+		{"rewindable", func() io.Reader { return bytes.NewReader([]byte("query")) }, 3},
+		{"not rewindable", func() io.Reader { return io.MultiReader(strings.NewReader("query")) }, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL, tt.body())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := newOptions(WithRetry(3), WithBackoff(time.Millisecond, time.Millisecond, 0))
+
+			var out any
+			if err := do(server.Client(), opts, req, &out); err == nil {
+				t.Fatal("do() error = nil, want an error")
+			}
+
+			if len(bodies) != tt.requests {
+				t.Fatalf("got %d requests, want %d", len(bodies), tt.requests)
+			}
+
+			for i, body := range bodies {
+				if body != "query" {
+					t.Errorf("body of the request %d = %q, want %q", i+1, body, "query")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package hn_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestGetDecodeError(t *testing.T) {
+	page := "<html>" + strings.Repeat("x", 4*hn.MaxDecodeErrorBody) + "</html>"
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantErr     error
+		wantBody    string
+	}{
+		{"html page as json", "application/json", page, nil, page[:hn.MaxDecodeErrorBody]},
+		{"html content type", "text/html", page, hn.ErrUnexpectedContentType, page[:hn.MaxDecodeErrorBody]},
+		{"short body", "", `{"id": `, nil, `{"id": `},
+		{"wrong field type", "application/json", `{"id": "one"}`, nil, `{"id": "one"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{
+				handler: func(w http.ResponseWriter, r *http.Request) bool {
+					w.Header().Set("Content-Type", tt.contentType)
+					_, _ = w.Write([]byte(tt.body))
+
+					return true
+				},
+			}
+
+			client := newTestClient(t, f)
+
+			_, err := client.Items.Get(context.Background(), 1)
+
+			var decodeErr *hn.DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("Get() error = %v, want a *DecodeError", err)
+			}
+
+			if !strings.HasSuffix(decodeErr.URL, "/item/1.json") {
+				t.Errorf("URL = %q, want the item URL", decodeErr.URL)
+			}
+
+			if string(decodeErr.Body) != tt.wantBody {
+				t.Errorf("Body = %q (%d bytes), want %q", decodeErr.Body, len(decodeErr.Body), tt.wantBody)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		wantErr     bool
+	}{
+		{"application/json", false},
+		{"application/json; charset=utf-8", false},
+		{"application/problem+json", false},
+		{"", false},
+		{"text/html; charset=utf-8", true},
+		{"text/plain", true},
+		{"application/jsonx", true},
+		{"invalid;;type", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			f := &fixture{
+				handler: func(w http.ResponseWriter, r *http.Request) bool {
+					w.Header().Set("Content-Type", tt.contentType)
+					_, _ = w.Write([]byte(`{"id":1,"type":"story"}`))
+
+					return true
+				},
+			}
+
+			client := newTestClient(t, f)
+
+			item, err := client.Items.Get(context.Background(), 1)
+			if !tt.wantErr {
+				if err != nil || item.ID != 1 {
+					t.Errorf("Get() = %d, %v, want item 1", item.ID, err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, hn.ErrUnexpectedContentType) {
+				t.Fatalf("Get() error = %v, want ErrUnexpectedContentType", err)
+			}
+
+			var decodeErr *hn.DecodeError
+			if !errors.As(err, &decodeErr) || string(decodeErr.Body) != `{"id":1,"type":"story"}` {
+				t.Errorf("Get() error = %v, want a *DecodeError with the body", err)
+			}
+
+			if !strings.Contains(err.Error(), tt.contentType) {
+				t.Errorf("Get() error = %v, want the content type in the message", err)
+			}
+		})
+	}
+}
+
+func TestGetResponseTooLarge(t *testing.T) {
+	item := newStory(1, 10)
+	item.Title = strings.Repeat("a", 1000)
+
+	f := &fixture{items: itemsOf(item)}
+
+	client := newTestClient(t, f, hn.WithMaxResponseBytes(100))
+
+	_, err := client.Items.Get(context.Background(), 1)
+	if !errors.Is(err, hn.ErrResponseTooLarge) {
+		t.Fatalf("Get() error = %v, want ErrResponseTooLarge", err)
+	}
+
+	var decodeErr *hn.DecodeError
+	if errors.As(err, &decodeErr) {
+		t.Errorf("Get() error = %v, want a read error instead of a *DecodeError", err)
+	}
+}
+
+func TestGetConditionalCache(t *testing.T) {
+	f := &fixture{items: itemsOf(newStory(1, 10))}
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+
+		return false
+	}
+
+	client := newTestClient(t, f, hn.WithConditionalCache())
+
+	for range 2 {
+		item, err := client.Items.Get(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		if item.Title != "Story 1" {
+			t.Errorf("Title = %q, want %q", item.Title, "Story 1")
+		}
+	}
+
+	if n := f.count("/item/1.json"); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+}
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []uint
+		wantErr error
+	}{
+		{"list", `[3, 1, 2]`, []uint{3, 1, 2}, nil},
+		{"trailing newline", "[1]\n", []uint{1}, nil},
+		{"large list", "[" + strings.Repeat("1,", 100000) + "1]", slices.Repeat([]uint{1}, 100001), nil},
+		{"null", `null`, nil, hn.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{handler: func(w http.ResponseWriter, r *http.Request) bool {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+
+				return true
+			}}
+
+			server := httptest.NewServer(f)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/list.json", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := hn.Do[[]uint](server.Client(), req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Do() = %d IDs, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
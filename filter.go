@@ -0,0 +1,85 @@
+package hn
+
+import "time"
+
+// And returns a filter that matches an item only if all the given filters match it.
+// A nil filter matches any item.
+func And(filters ...func(Item) bool) func(Item) bool {
+	return func(item Item) bool {
+		for _, filter := range filters {
+			if filter != nil && !filter(item) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or returns a filter that matches an item if at least one of the given filters matches it.
+// A nil filter matches any item.
+func Or(filters ...func(Item) bool) func(Item) bool {
+	return func(item Item) bool {
+		for _, filter := range filters {
+			if filter == nil || filter(item) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not returns a filter that matches an item only if the given filter doesn't match it.
+func Not(filter func(Item) bool) func(Item) bool {
+	return func(item Item) bool {
+		return !filter(item)
+	}
+}
+
+// ByType returns a filter that matches the items of the specified type (e.g., StoryType).
+func ByType(t string) func(Item) bool {
+	return func(item Item) bool {
+		return item.Type == t
+	}
+}
+
+// ByAuthor returns a filter that matches the items submitted by the user with the given name.
+func ByAuthor(username string) func(Item) bool {
+	return func(item Item) bool {
+		return item.By == username
+	}
+}
+
+// MinScore returns a filter that matches the items with a score of at least n.
+func MinScore(n int) func(Item) bool {
+	return func(item Item) bool {
+		return item.Score >= n
+	}
+}
+
+// Since returns a filter that matches the items created at or after t.
+func Since(t time.Time) func(Item) bool {
+	return func(item Item) bool {
+		return !item.Time.Before(t)
+	}
+}
+
+// FilterAlive reports whether the item is neither deleted nor dead.
+func FilterAlive(item Item) bool {
+	return !item.Deleted && !item.Dead
+}
+
+// Filter returns a new slice of the items (e.g., stories converted with ToList) for which keep returns true,
+// preserving their order. Unlike the filters of list operations, it works on any of the concrete item types.
+func Filter[S Sortable](items []S, keep func(S) bool) []S {
+	list := make([]S, 0, len(items))
+
+	for _, item := range items {
+		if keep(item) {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,194 @@
+package hn_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestWithSkipDeadDeleted(t *testing.T) {
+	deleted := newStory(2, 20)
+	deleted.Deleted = true
+
+	dead := newStory(3, 30)
+	dead.Dead = true
+
+	f := &fixture{items: itemsOf(newStory(1, 10), deleted, dead, newStory(4, 40))}
+
+	tests := []struct {
+		name   string
+		opts   []hn.Option
+		filter func(hn.Item) bool
+		want   []uint
+	}{
+		{"without option", nil, nil, []uint{1, 2, 3, 4}},
+		{"with option", []hn.Option{hn.WithSkipDeadDeleted()}, nil, []uint{1, 4}},
+		{"with option and filter", []hn.Option{hn.WithSkipDeadDeleted()}, hn.MinScore(20), []uint{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, f, tt.opts...)
+
+			items, err := client.Items.List(context.Background(), idRange(1, 4), tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if got := hn.IDsOf(items); !slices.Equal(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDefaultFilter(t *testing.T) {
+	comment := newComment(3, 1)
+	comment.Score = 30
+
+	f := &fixture{
+		items: itemsOf(newStory(1, 10), newStory(2, 20), comment, newStory(4, 40)),
+		users: map[string]hn.User{"pg": {ID: "pg", Submitted: []uint{4, 3, 2, 1}}},
+		lists: map[string][]uint{"topstories": {1, 2, 3, 4}},
+	}
+
+	stories := hn.ByType(hn.StoryType)
+
+	tests := []struct {
+		name   string
+		opts   []hn.Option
+		filter func(hn.Item) bool
+		want   []uint
+	}{
+		{"no filters", nil, nil, []uint{1, 2, 3, 4}},
+		{"call filter only", nil, hn.MinScore(20), []uint{2, 3, 4}},
+		{"default filter only", []hn.Option{hn.WithDefaultFilter(stories)}, nil, []uint{1, 2, 4}},
+		{"combined filters", []hn.Option{hn.WithDefaultFilter(stories)}, hn.MinScore(20), []uint{2, 4}},
+		{"nil default filter", []hn.Option{hn.WithDefaultFilter(nil)}, hn.MinScore(20), []uint{2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, f, tt.opts...)
+			ctx := context.Background()
+
+			items, err := client.Items.List(ctx, idRange(1, 4), tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if got := hn.IDsOf(items); !slices.Equal(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+
+			items, err = client.Live.TopList(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("TopList() error = %v", err)
+			}
+
+			if got := hn.IDsOf(items); !slices.Equal(got, tt.want) {
+				t.Errorf("TopList() = %v, want %v", got, tt.want)
+			}
+
+			items, err = client.Users.Items(ctx, "pg", tt.filter, 0)
+			if err != nil {
+				t.Fatalf("Users.Items() error = %v", err)
+			}
+
+			want := slices.Clone(tt.want)
+			slices.Reverse(want)
+
+			if got := hn.IDsOf(items); !slices.Equal(got, want) {
+				t.Errorf("Users.Items() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFilters(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	story := newStory(1, 50)
+	story.By, story.Time = "pg", hn.Timestamp{Time: now}
+
+	comment := newComment(2, 1)
+	comment.By, comment.Time = "dang", hn.Timestamp{Time: now.Add(-time.Hour)}
+
+	dead := newStory(3, 5)
+	dead.By, dead.Dead = "pg", true
+
+	items := []hn.Item{story, comment, dead}
+
+	tests := []struct {
+		name   string
+		filter func(hn.Item) bool
+		want   []uint
+	}{
+		{"by type", hn.ByType(hn.StoryType), []uint{1, 3}},
+		{"by author", hn.ByAuthor("pg"), []uint{1, 3}},
+		{"min score", hn.MinScore(50), []uint{1}},
+		{"since", hn.Since(now.Add(-time.Hour)), []uint{1, 2}},
+		{"alive", hn.FilterAlive, []uint{1, 2}},
+		{"not", hn.Not(hn.ByType(hn.StoryType)), []uint{2}},
+		{"and", hn.And(hn.ByAuthor("pg"), hn.FilterAlive), []uint{1}},
+		{"and with nil", hn.And(nil, hn.ByAuthor("dang")), []uint{2}},
+		{"empty and", hn.And(), []uint{1, 2, 3}},
+		{"or", hn.Or(hn.ByAuthor("dang"), hn.MinScore(50)), []uint{1, 2}},
+		{"or with nil", hn.Or(hn.ByAuthor("nobody"), nil), []uint{1, 2, 3}},
+		{"empty or", hn.Or(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uint
+
+			for _, item := range items {
+				if tt.filter(item) {
+					got = append(got, item.ID)
+				}
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matched %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterGeneric(t *testing.T) {
+	stories := hn.ToList[hn.Story]([]hn.Item{newStory(1, 10), newStory(2, 200), newStory(3, 150)})
+
+	comment := newComment(5, 4)
+	comment.By = "pg"
+
+	comments := hn.ToList[hn.Comment]([]hn.Item{newComment(4, 1), comment, newComment(6, 1)})
+
+	tests := []struct {
+		name string
+		got  []uint
+		want []uint
+	}{
+		{"stories", hn.IDsOf(hn.Filter(stories, func(s hn.Story) bool { return s.Score > 100 })), []uint{2, 3}},
+		{"no stories", hn.IDsOf(hn.Filter(stories, func(s hn.Story) bool { return s.Score > 1000 })), []uint{}},
+		{"all stories", hn.IDsOf(hn.Filter(stories, func(hn.Story) bool { return true })), []uint{1, 2, 3}},
+		{"comments", hn.IDsOf(hn.Filter(comments, func(c hn.Comment) bool { return c.Parent == 1 })), []uint{4, 6}},
+		{"comments by author", hn.IDsOf(hn.Filter(comments, func(c hn.Comment) bool { return c.By == "pg" })), []uint{5}},
+		{"empty", hn.IDsOf(hn.Filter([]hn.Comment(nil), func(hn.Comment) bool { return true })), []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// The original slice is not modified.
+	if got := hn.IDsOf(stories); !slices.Equal(got, []uint{1, 2, 3}) {
+		t.Errorf("stories = %v after Filter, want them unchanged", got)
+	}
+}
@@ -0,0 +1,85 @@
+package hn
+
+import (
+	"context"
+	"sync"
+)
+
+// flight is a request for an item shared by the concurrent calls of ItemService.Get for the same ID.
+// The request is canceled when all the callers waiting for it have given up.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	item    Item
+	err     error
+}
+
+// flightGroup deduplicates the concurrent requests for the same item.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[uint]*flight
+}
+
+// do calls fetch for the item with the specified ID, or joins the request that is already in flight for it.
+// The context of the shared request keeps the values of ctx, but it's canceled only when ctx and the contexts
+// of all other callers that joined the request are done, so a caller giving up doesn't fail the others.
+func (g *flightGroup) do(ctx context.Context, id uint, fetch func(context.Context, uint) (Item, error)) (Item, error) {
+	g.mu.Lock()
+
+	f, ok := g.flights[id]
+	if !ok {
+		shared, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+
+		if g.flights == nil {
+			g.flights = make(map[uint]*flight)
+		}
+
+		g.flights[id] = f
+
+		go func() {
+			defer close(f.done)
+			defer cancel()
+
+			f.item, f.err = fetch(shared, id)
+
+			g.forget(id, f)
+		}()
+	}
+
+	f.waiters++
+
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.item, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody waits for the result anymore, so the next call starts a new request.
+			f.cancel()
+
+			if g.flights[id] == f {
+				delete(g.flights, id)
+			}
+		}
+
+		g.mu.Unlock()
+
+		return Item{}, ctx.Err()
+	}
+}
+
+// forget removes the finished flight, unless it was already replaced by a new one.
+func (g *flightGroup) forget(id uint, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.flights[id] == f {
+		delete(g.flights, id)
+	}
+}
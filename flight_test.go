@@ -0,0 +1,182 @@
+package hn_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestGetDeduplicatesConcurrentCalls(t *testing.T) {
+	release := make(chan struct{})
+
+	f := &fixture{
+		items: itemsOf(newStory(1, 10)),
+		handler: func(w http.ResponseWriter, r *http.Request) bool {
+			<-release
+			return false
+		},
+	}
+
+	client := newTestClient(t, f)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			item, err := client.Items.Get(context.Background(), 1)
+			if err != nil || item.ID != 1 {
+				t.Errorf("Get() = %v, %v, want item 1", item.ID, err)
+			}
+		}()
+	}
+
+	// Let all the calls join the request before it's answered.
+	waitFor(t, func() bool { return f.count("/item/1.json") == 1 })
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := f.count("/item/1.json"); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestGetCanceledCallerDoesntFailOthers(t *testing.T) {
+	release := make(chan struct{})
+
+	f := &fixture{
+		items: itemsOf(newStory(1, 10)),
+		handler: func(w http.ResponseWriter, r *http.Request) bool {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+
+			return false
+		},
+	}
+
+	client := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := client.Items.Get(ctx, 1)
+		canceled <- err
+	}()
+
+	waitFor(t, func() bool { return f.count("/item/1.json") == 1 })
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.Items.Get(context.Background(), 1)
+		result <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled Get() error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+
+	if err := <-result; err != nil {
+		t.Errorf("other Get() error = %v, want nil", err)
+	}
+
+	if n := f.count("/item/1.json"); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestGetCancelsSharedRequestWhenAllCallersGiveUp(t *testing.T) {
+	var aborted atomic.Int32
+
+	f := &fixture{
+		handler: func(w http.ResponseWriter, r *http.Request) bool {
+			<-r.Context().Done()
+			aborted.Add(1)
+
+			return true
+		},
+	}
+
+	// The HTTP client has no timeout, so only the cancellation can stop the request.
+	client := newTestClient(t, f)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for range 3 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := client.Items.Get(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Get() error = %v, want context.DeadlineExceeded", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	waitFor(t, func() bool { return aborted.Load() == 1 })
+}
+
+func TestListStopsQueuedRequestsAfterDeadline(t *testing.T) {
+	f := &fixture{items: make(map[uint]hn.Item)}
+
+	var ids []uint
+
+	for id := uint(1); id <= 50; id++ {
+		f.items[id] = newStory(id, 1)
+		ids = append(ids, id)
+	}
+
+	client := newTestClient(t, f, hn.WithRateLimit(5))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Items.List(ctx, ids, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("List() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	sent := f.total()
+
+	// The requests waiting for the rate limiter must give up, instead of being sent later.
+	time.Sleep(time.Second)
+
+	if n := f.total(); n != sent {
+		t.Errorf("got %d requests after List returned, want %d", n, sent)
+	}
+
+	waitFor(t, func() bool { return leaked() == "" })
+
+	// The slots of the canceled requests must be given back, so the next request is not delayed by them.
+	start := time.Now()
+
+	if _, err := client.Items.Get(context.Background(), ids[len(ids)-1]); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Get() after the canceled List took %v, want at most 500ms", elapsed)
+	}
+}
@@ -0,0 +1,199 @@
+package hn_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+	"github.com/imotkin/hn-client/hntest"
+)
+
+// fixture is the data served by a fake Hacker News API started with newTestClient.
+type fixture struct {
+	items map[uint]hn.Item
+	users map[string]hn.User
+	lists map[string][]uint
+
+	// handler, if set, is called before the default handler and serves the request if it returns true.
+	handler func(w http.ResponseWriter, r *http.Request) bool
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+// count returns the number of requests received for the path (e.g., "/item/1.json").
+func (f *fixture) count(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[path]
+}
+
+// total returns the number of all requests received.
+func (f *fixture) total() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var n int
+	for _, c := range f.requests {
+		n += c
+	}
+
+	return n
+}
+
+// ServeHTTP counts the request and serves it with the handler of the fixture, if any, or with the fixture data.
+func (f *fixture) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	if f.requests == nil {
+		f.requests = make(map[string]int)
+	}
+	f.requests[r.URL.Path]++
+	f.mu.Unlock()
+
+	if f.handler != nil && f.handler(w, r) {
+		return
+	}
+
+	hntest.NewHandler(f.items, f.users, f.lists).ServeHTTP(w, r)
+}
+
+// newTestClient starts a fake API serving the fixture and returns a client sending requests to it.
+func newTestClient(t testing.TB, f *fixture, opts ...hn.Option) *hn.Client {
+	t.Helper()
+
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	return hn.NewClient(server.Client(), append([]hn.Option{hn.WithBaseURL(server.URL)}, opts...)...)
+}
+
+// itemsOf returns the items keyed by their IDs.
+func itemsOf(items ...hn.Item) map[uint]hn.Item {
+	m := make(map[uint]hn.Item, len(items))
+	for _, item := range items {
+		m[item.ID] = item
+	}
+
+	return m
+}
+
+func newItem(id uint, itemType string) hn.Item {
+	var item hn.Item
+	item.ID, item.Type = id, itemType
+
+	return item
+}
+
+func newStory(id uint, score int, kids ...uint) hn.Item {
+	item := newItem(id, hn.StoryType)
+	item.Score = score
+	item.Title = "Story " + strconv.Itoa(int(id))
+	item.Kids = kids
+
+	return item
+}
+
+func newComment(id, parent uint, kids ...uint) hn.Item {
+	item := newItem(id, hn.CommentType)
+	item.Parent = parent
+	item.Text = "Comment " + strconv.Itoa(int(id))
+	item.Kids = kids
+
+	return item
+}
+
+// leaked returns the stack of a goroutine running the code of the package (other than the tests),
+// or an empty string if there are no such goroutines.
+func leaked() string {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if strings.Contains(stack, "hn-client.(*") && !strings.Contains(stack, "_test.go") {
+			return stack
+		}
+	}
+
+	return ""
+}
+
+// waitFor waits until cond is true, failing the test if it takes more than 2 seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition was not met in time")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// dropConnection closes the connection of the request without a response, so the client sees a network error.
+func dropConnection(w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err == nil {
+		conn.Close()
+	}
+}
+
+// idRange returns the IDs from first to last, inclusive.
+func idRange(first, last uint) []uint {
+	ids := make([]uint, 0, last-first+1)
+	for id := first; id <= last; id++ {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func itoa(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// inFlight counts the item requests being served at once by a fixture.
+type inFlight struct {
+	mu        sync.Mutex
+	cur, peak int
+}
+
+// handler returns a fixture handler that holds each item request for delay, so the concurrent requests overlap,
+// and then lets the fixture serve it.
+func (p *inFlight) handler(delay time.Duration) func(w http.ResponseWriter, r *http.Request) bool {
+	return func(w http.ResponseWriter, r *http.Request) bool {
+		if !strings.HasPrefix(r.URL.Path, "/item/") {
+			return false
+		}
+
+		p.mu.Lock()
+		p.cur++
+		p.peak = max(p.peak, p.cur)
+		p.mu.Unlock()
+
+		time.Sleep(delay)
+
+		p.mu.Lock()
+		p.cur--
+		p.mu.Unlock()
+
+		return false
+	}
+}
+
+// max returns the largest number of item requests served at once.
+func (p *inFlight) max() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.peak
+}
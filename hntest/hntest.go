@@ -0,0 +1,95 @@
+// Package hntest provides an offline Hacker News API server for testing the code that uses hn.Client.
+package hntest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+// Option configures the data served by a handler returned by NewHandler that doesn't fit in its maps.
+type Option func(*data)
+
+type data struct {
+	profiles []string
+}
+
+// WithUpdatedProfiles sets the names of the changed profiles served by /updates.json.
+func WithUpdatedProfiles(names ...string) Option {
+	return func(d *data) {
+		d.profiles = names
+	}
+}
+
+// NewServer returns a started test server that serves the given data in the format of the Hacker News API
+// (see NewHandler). The caller should call Close when finished, to shut the server down.
+func NewServer(items map[uint]hn.Item, users map[string]hn.User, lists map[string][]uint, opts ...Option) *httptest.Server {
+	return httptest.NewServer(NewHandler(items, users, lists, opts...))
+}
+
+// NewHandler returns a handler that serves the given data in the format of the Hacker News API:
+//
+//   - /item/<id>.json serves the items from items
+//   - /user/<name>.json serves the users from users
+//   - /<name>.json serves the ID lists from lists (e.g., "topstories" or "newstories")
+//   - /maxitem.json serves the maximum ID of items
+//   - /updates.json serves the IDs from lists["updates"] and the profiles set with WithUpdatedProfiles
+//
+// Missing items, users or lists are served as null, like the Hacker News API does.
+// The maps are read on each request, so they must not be changed while the handler is in use.
+func NewHandler(items map[uint]hn.Item, users map[string]hn.User, lists map[string][]uint, opts ...Option) http.Handler {
+	var d data
+	for _, opt := range opts {
+		opt(&d)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, ok := strings.CutSuffix(r.URL.Path, ".json")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		var value any
+
+		switch {
+		case strings.HasPrefix(path, "/item/"):
+			id, err := strconv.ParseUint(strings.TrimPrefix(path, "/item/"), 10, 0)
+			if item, ok := items[uint(id)]; ok && err == nil {
+				value = item
+			}
+		case strings.HasPrefix(path, "/user/"):
+			if user, ok := users[strings.TrimPrefix(path, "/user/")]; ok {
+				value = user
+			}
+		case path == "/maxitem":
+			var maxID uint
+			for id := range items {
+				maxID = max(maxID, id)
+			}
+
+			value = maxID
+		case path == "/updates":
+			value = hn.Update{Items: lists["updates"], Profiles: d.profiles}
+		default:
+			if list, ok := lists[strings.TrimPrefix(path, "/")]; ok {
+				value = list
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		_ = json.NewEncoder(w).Encode(value)
+	})
+}
+
+// Client returns a new client that sends requests to the test server.
+func Client(server *httptest.Server, opts ...hn.Option) *hn.Client {
+	opts = append([]hn.Option{hn.WithBaseURL(server.URL)}, opts...)
+
+	return hn.NewClient(server.Client(), opts...)
+}
@@ -0,0 +1,107 @@
+package hntest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+	"github.com/imotkin/hn-client/hntest"
+)
+
+func newClient(t *testing.T) *hn.Client {
+	t.Helper()
+
+	var story, comment hn.Item
+
+	story.ID, story.Type, story.Title, story.Kids = 1, hn.StoryType, "Story &amp; more", []uint{2}
+	comment.ID, comment.Type, comment.Parent = 2, hn.CommentType, 1
+
+	server := hntest.NewServer(
+		map[uint]hn.Item{1: story, 2: comment},
+		map[string]hn.User{"pg": {ID: "pg", Karma: 100, Submitted: []uint{1}}},
+		map[string][]uint{"topstories": {2, 1}, "updates": {1}},
+		hntest.WithUpdatedProfiles("pg", "dang"),
+	)
+	t.Cleanup(server.Close)
+
+	return hntest.Client(server)
+}
+
+func TestServer(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		get  func() (any, error)
+		want any
+	}{
+		{"item", func() (any, error) {
+			item, err := client.Items.Get(ctx, 1)
+			return item.Title, err
+		}, "Story & more"},
+		{"user", func() (any, error) {
+			user, err := client.Users.Get(ctx, "pg")
+			return user.Karma, err
+		}, 100},
+		{"list", func() (any, error) {
+			ids, err := client.Live.Top(ctx)
+			return slices.Equal(ids, []uint{2, 1}), err
+		}, true},
+		{"max item", func() (any, error) {
+			return client.Live.MaxID(ctx)
+		}, uint(2)},
+		{"updates", func() (any, error) {
+			update, err := client.Live.Update(ctx)
+			return slices.Equal(update.Items, []uint{1}) && slices.Equal(update.Profiles, []string{"pg", "dang"}), err
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerMissing(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	if _, err := client.Items.Get(ctx, 3); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("Items.Get(3) error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := client.Users.Get(ctx, "dang"); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("Users.Get(dang) error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := client.Live.Best(ctx); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("Live.Best() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestServerNotJSON(t *testing.T) {
+	server := hntest.NewServer(nil, nil, nil)
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/item/1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status code = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,14 @@
+package hn
+
+import "time"
+
+// RequestInfo describes an attempt of an HTTP request sent to the API, passed to the hook set with WithRequestHook.
+type RequestInfo struct {
+	Method     string
+	URL        string
+	StatusCode int           // 0 if no response was received
+	Bytes      int64         // number of bytes read from the response body
+	Elapsed    time.Duration // time from sending the request to decoding the response, without waiting for the limits (0 if not sent)
+	Err        error         // error of the request, if any
+	Attempt    int           // number of the attempt, starting from 0 (greater than 0 for retries)
+}
@@ -0,0 +1,165 @@
+package hn_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestWithRequestHook(t *testing.T) {
+	type attempt struct {
+		status  int
+		attempt int
+		failed  bool
+	}
+
+	tests := []struct {
+		name    string
+		handler func(w http.ResponseWriter, r *http.Request, n int) bool
+		want    []attempt
+	}{
+		{"success", func(http.ResponseWriter, *http.Request, int) bool { return false }, []attempt{{200, 0, false}}},
+		{"client error", func(w http.ResponseWriter, _ *http.Request, _ int) bool {
+			w.WriteHeader(http.StatusBadRequest)
+			return true
+		}, []attempt{{400, 0, true}}},
+		{"retried", func(w http.ResponseWriter, _ *http.Request, n int) bool {
+			if n == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return true
+			}
+
+			return false
+		}, []attempt{{503, 0, true}, {200, 1, false}}},
+		{"network error", func(w http.ResponseWriter, _ *http.Request, n int) bool {
+			if n == 1 {
+				dropConnection(w)
+				return true
+			}
+
+			return false
+		}, []attempt{{0, 0, true}, {200, 1, false}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{items: itemsOf(newStory(1, 10))}
+			f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+				return tt.handler(w, r, f.count(r.URL.Path))
+			}
+
+			var (
+				mu    sync.Mutex
+				infos []hn.RequestInfo
+			)
+
+			client := newTestClient(t, f,
+				hn.WithRetry(2),
+				hn.WithBackoff(time.Millisecond, time.Millisecond, 0),
+				hn.WithRequestHook(func(info hn.RequestInfo) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					infos = append(infos, info)
+				}),
+			)
+
+			_, _ = client.Items.Get(context.Background(), 1)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if len(infos) != len(tt.want) {
+				t.Fatalf("hook called %d times, want %d", len(infos), len(tt.want))
+			}
+
+			for i, info := range infos {
+				want := tt.want[i]
+
+				if info.StatusCode != want.status || info.Attempt != want.attempt || (info.Err != nil) != want.failed {
+					t.Errorf("attempt %d: status %d, attempt %d, error %v, want %d, %d, error: %v",
+						i, info.StatusCode, info.Attempt, info.Err, want.status, want.attempt, want.failed)
+				}
+
+				if info.Method != http.MethodGet || !strings.HasSuffix(info.URL, "/item/1.json") {
+					t.Errorf("attempt %d: request %s %s, want GET of the item URL", i, info.Method, info.URL)
+				}
+
+				if info.Elapsed <= 0 {
+					t.Errorf("attempt %d: Elapsed = %v, want a positive duration", i, info.Elapsed)
+				}
+
+				if !want.failed && info.Bytes == 0 {
+					t.Errorf("attempt %d: Bytes = 0, want the size of the body", i)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestHookElapsed(t *testing.T) {
+	const (
+		delay    = 50 * time.Millisecond
+		requests = 5
+	)
+
+	tests := []struct {
+		name string
+		opt  hn.Option
+	}{
+		{"concurrency limit", hn.WithMaxConcurrency(1)},
+		{"rate limit", hn.WithRateLimit(1 / delay.Seconds())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{
+				items: itemsOf(newStory(1, 1), newStory(2, 1), newStory(3, 1), newStory(4, 1), newStory(5, 1)),
+				handler: func(http.ResponseWriter, *http.Request) bool {
+					time.Sleep(delay)
+					return false
+				},
+			}
+
+			var (
+				mu      sync.Mutex
+				elapsed []time.Duration
+				start   = time.Now()
+			)
+
+			client := newTestClient(t, f, tt.opt, hn.WithRequestHook(func(info hn.RequestInfo) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				elapsed = append(elapsed, info.Elapsed)
+			}))
+
+			if _, err := client.Items.List(context.Background(), idRange(1, requests), nil); err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if len(elapsed) != requests {
+				t.Fatalf("hook called %d times, want %d", len(elapsed), requests)
+			}
+
+			// The last requests wait for the limit for several delays, which must not be counted.
+			if total := time.Since(start); total < time.Duration(requests-1)*delay {
+				t.Fatalf("List() took %v, want the requests to wait for the limit", total)
+			}
+
+			for _, d := range elapsed {
+				if d < delay || d > 2*delay {
+					t.Errorf("Elapsed = %v, want the time of a single request (%v)", d, delay)
+				}
+			}
+		})
+	}
+}
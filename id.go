@@ -0,0 +1,101 @@
+package hn
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// id is an ID decoded from a JSON integer, a float without a fractional part (e.g., 12345.0)
+// or a string containing either of them, which some mirrors of the API return instead of integers.
+type id uint
+
+func (i *id) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	s := string(data)
+
+	if data[0] == '"' {
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return err
+		}
+	}
+
+	if v, err := strconv.ParseUint(s, 10, 0); err == nil {
+		*i = id(v)
+		return nil
+	}
+
+	// math.MaxUint converted to float64 is rounded up to 2^64, which doesn't fit in a uint,
+	// so the comparison must exclude it.
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || f != math.Trunc(f) || f >= math.MaxUint {
+		return fmt.Errorf("invalid ID: %s", data)
+	}
+
+	*i = id(f)
+
+	return nil
+}
+
+// ids is a list of IDs, each of them decoded like id.
+type ids []uint
+
+func (l *ids) UnmarshalJSON(data []byte) error {
+	var list []id
+
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return err
+	}
+
+	if list == nil {
+		*l = nil
+		return nil
+	}
+
+	*l = make(ids, len(list))
+
+	for i, v := range list {
+		(*l)[i] = uint(v)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes an item, accepting the IDs (id, parent, kids, parts and poll)
+// encoded as integers, floats without a fractional part or strings.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	type item Item
+
+	aux := struct {
+		*item
+
+		ID     id  `json:"id"`
+		Parent id  `json:"parent"`
+		Poll   id  `json:"poll"`
+		Kids   ids `json:"kids"`
+		Parts  ids `json:"parts"`
+	}{
+		item:   (*item)(i),
+		ID:     id(i.ID),
+		Parent: id(i.Parent),
+		Poll:   id(i.Poll),
+		Kids:   i.Kids,
+		Parts:  i.Parts,
+	}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	i.ID, i.Parent, i.Poll = uint(aux.ID), uint(aux.Parent), uint(aux.Poll)
+	i.Kids, i.Parts = aux.Kids, aux.Parts
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package hn_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestItemIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    uint
+		wantErr bool
+	}{
+		{"integer", `123`, 123, false},
+		{"float", `123.0`, 123, false},
+		{"string", `"123"`, 123, false},
+		{"float string", `"123.0"`, 123, false},
+		{"null", `null`, 0, false},
+		{"max uint", `18446744073709551615`, 1<<64 - 1, false},
+		{"largest float below 2^64", `18446744073709549568.0`, 18446744073709549568, false},
+		{"2^64 float", `18446744073709551616.0`, 0, true},
+		{"2^64 integer", `18446744073709551616`, 0, true},
+		{"fraction", `1.5`, 0, true},
+		{"negative", `-1`, 0, true},
+		{"not a number", `"abc"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item hn.Item
+
+			err := json.Unmarshal([]byte(`{"id":`+tt.id+`}`), &item)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error: %v", tt.id, err, tt.wantErr)
+			}
+
+			if err == nil && item.ID != tt.want {
+				t.Errorf("ID = %d, want %d", item.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package hn
+
+// ItemKind represents the type of an item as an enumeration,
+// which is safer to switch on than the type strings (e.g., StoryType).
+type ItemKind int
+
+const (
+	KindUnknown ItemKind = iota
+	KindStory
+	KindComment
+	KindAsk
+	KindJob
+	KindPoll
+	KindPollOption
+)
+
+var kinds = map[string]ItemKind{
+	StoryType:      KindStory,
+	CommentType:    KindComment,
+	AskType:        KindAsk,
+	JobType:        KindJob,
+	PollType:       KindPoll,
+	PollOptionType: KindPollOption,
+}
+
+// KindOf returns the kind of the given item type, or KindUnknown if the type is not recognized.
+func KindOf(t string) ItemKind {
+	return kinds[t]
+}
+
+// Kind returns the kind of the item, or KindUnknown if its type is not recognized.
+func (i Item) Kind() ItemKind {
+	return KindOf(i.Type)
+}
+
+// String returns the type string of the kind (e.g., StoryType), or "unknown" for KindUnknown.
+func (k ItemKind) String() string {
+	for t, kind := range kinds {
+		if kind == k {
+			return t
+		}
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,44 @@
+package hn_test
+
+import (
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want hn.ItemKind
+		name string
+	}{
+		{hn.StoryType, hn.KindStory, "story"},
+		{hn.CommentType, hn.KindComment, "comment"},
+		{hn.AskType, hn.KindAsk, "ask"},
+		{hn.JobType, hn.KindJob, "job"},
+		{hn.PollType, hn.KindPoll, "poll"},
+		{hn.PollOptionType, hn.KindPollOption, "pollopt"},
+		{"", hn.KindUnknown, "unknown"},
+		{"Story", hn.KindUnknown, "unknown"},
+		{"link", hn.KindUnknown, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			if got := hn.KindOf(tt.typ); got != tt.want {
+				t.Errorf("KindOf(%q) = %v, want %v", tt.typ, got, tt.want)
+			}
+
+			var item hn.Item
+			item.Type = tt.typ
+
+			if got := item.Kind(); got != tt.want {
+				t.Errorf("Kind() = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.want.String(); got != tt.name {
+				t.Errorf("String() = %q, want %q", got, tt.name)
+			}
+		})
+	}
+}
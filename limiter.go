@@ -0,0 +1,88 @@
+package hn
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces the requests evenly, so at most one request is sent per interval (see WithRateLimit).
+// All methods are safe to call on a nil limiter, which doesn't limit the requests.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu       sync.Mutex
+	next     time.Time
+	canceled map[int64]bool // the canceled slots that are still followed by a reserved one, by Unix nanoseconds
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait reserves the next free slot for a request and waits for it, or until ctx is done.
+// The slot of a canceled wait is given back, so it doesn't delay the later requests.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+
+	at := time.Now()
+	if l.next.After(at) {
+		at = l.next
+	} else {
+		// All the reserved slots are in the past, so the canceled ones can't be given back anymore.
+		clear(l.canceled)
+	}
+
+	l.next = at.Add(l.interval)
+
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel(at)
+		return ctx.Err()
+	}
+}
+
+// cancel gives back the slot reserved at the given time. Only the last slots can be given back,
+// so the slot is kept as canceled until all the slots reserved after it are canceled too.
+func (l *rateLimiter) cancel(at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.next.Equal(at.Add(l.interval)) {
+		if l.canceled == nil {
+			l.canceled = make(map[int64]bool)
+		}
+
+		l.canceled[at.UnixNano()] = true
+
+		return
+	}
+
+	l.next = at
+
+	for {
+		prev := l.next.Add(-l.interval).UnixNano()
+		if !l.canceled[prev] {
+			return
+		}
+
+		delete(l.canceled, prev)
+		l.next = l.next.Add(-l.interval)
+	}
+}
@@ -0,0 +1,189 @@
+package hn
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+var siteURL = "https://news.ycombinator.com"
+
+// SetSiteURL sets the base URL of the Hacker News website used to build permalinks.
+// The default value is "https://news.ycombinator.com".
+func SetSiteURL(u string) {
+	siteURL = strings.TrimSuffix(u, "/")
+}
+
+// Permalink returns the URL of the item page on the Hacker News website.
+func (i baseItem) Permalink() string {
+	return fmt.Sprintf("%s/item?id=%d", siteURL, i.ID)
+}
+
+// Permalink returns the URL of the user page on the Hacker News website.
+func (u User) Permalink() string {
+	return siteURL + "/user?id=" + url.QueryEscape(u.ID)
+}
+
+// Domain returns the host of the item URL without the "www." prefix (e.g., "github.com").
+// It returns an empty string if the item has no URL (e.g., an ask or a text post) or the URL is invalid.
+func (i Item) Domain() string {
+	return domain(i.URL)
+}
+
+// Domain returns the host of the story URL without the "www." prefix (e.g., "github.com").
+// It returns an empty string if the story has no URL (e.g., a text post) or the URL is invalid.
+func (s Story) Domain() string {
+	return domain(s.URL)
+}
+
+// Domain returns the host of the job URL without the "www." prefix (e.g., "github.com").
+// It returns an empty string if the job has no URL or the URL is invalid.
+func (j Job) Domain() string {
+	return domain(j.URL)
+}
+
+// IsTextPost reports whether the item is a text post: an ask, or a story without a URL and with a text.
+func (i Item) IsTextPost() bool {
+	switch i.Type {
+	case AskType:
+		return true
+	case StoryType:
+		return i.URL == "" && i.Text != ""
+	}
+
+	return false
+}
+
+// IsLink reports whether the item is a link to another page: a story or a job with a URL.
+func (i Item) IsLink() bool {
+	return (i.Type == StoryType || i.Type == JobType) && i.URL != ""
+}
+
+// IsTextPost reports whether the story is a text post, i.e., it has no URL and has a text.
+func (s Story) IsTextPost() bool {
+	return s.URL == "" && s.Text != ""
+}
+
+// IsLink reports whether the story is a link to another page, i.e., it has a URL.
+func (s Story) IsLink() bool {
+	return s.URL != ""
+}
+
+// IsTextPost reports whether the ask is a text post, which is always true.
+func (a Ask) IsTextPost() bool {
+	return true
+}
+
+// IsLink reports whether the job is a link to another page (e.g., a careers page), i.e., it has a URL.
+func (j Job) IsLink() bool {
+	return j.URL != ""
+}
+
+func domain(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+// Link is a URL referenced by an item, either as the URL of the item or in its text.
+type Link struct {
+	ItemID uint
+	URL    string
+}
+
+// ExtractLinks returns the links referenced by the items of the thread tree: the URLs of the items
+// (e.g., the story at the root) and the links in their texts (e.g., the comments), in depth-first order.
+// If unique is true, only the first link with each URL is returned.
+func ExtractLinks(node *ThreadNode, unique bool) []Link {
+	var (
+		links []Link
+		seen  = make(map[string]bool)
+	)
+
+	add := func(id uint, u string) {
+		if unique && seen[u] {
+			return
+		}
+
+		seen[u] = true
+		links = append(links, Link{ItemID: id, URL: u})
+	}
+
+	Walk(node, func(_ int, item Item) bool {
+		if item.URL != "" {
+			add(item.ID, item.URL)
+		}
+
+		for _, href := range hrefs(item.markup(item.Text)) {
+			add(item.ID, href)
+		}
+
+		return true
+	})
+
+	return links
+}
+
+// hrefs returns the targets of the links (<a href="...">) in the HTML text, with the entities decoded.
+// The text must be the original HTML (see baseItem.markup): in the unescaped text,
+// the links typed by the users can't be told apart from the real ones.
+func hrefs(text string) []string {
+	var links []string
+
+	for {
+		start := indexTag(text, "a")
+		if start < 0 {
+			return links
+		}
+
+		text = text[start+1:]
+
+		end := strings.IndexByte(text, '>')
+		if end < 0 {
+			return links
+		}
+
+		if href := html.UnescapeString(attr(text[:end], "href")); href != "" {
+			links = append(links, href)
+		}
+
+		text = text[end+1:]
+	}
+}
+
+// indexTag returns the index of the first opening tag with the given lowercase name followed by a space
+// (e.g., "<a " or "<A "), or -1 if there is no such tag. Only the ASCII letters are compared case-insensitively,
+// so the index is always valid in text, unlike an index found in strings.ToLower(text).
+func indexTag(text, name string) int {
+	for i := 0; i+len(name)+2 <= len(text); i++ {
+		if text[i] != '<' || text[i+len(name)+1] != ' ' {
+			continue
+		}
+
+		tag := text[i+1 : i+len(name)+1]
+
+		if strings.EqualFold(tag, name) && isASCII(tag) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,220 @@
+package hn_test
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestExtractLinks(t *testing.T) {
+	root := newStory(1, 10, 2, 3)
+	root.URL = "https://example.com/post"
+
+	first := newComment(2, 1)
+	first.Text = `See <a href="https:&#x2F;&#x2F;go.dev&#x2F;doc" rel="nofollow">https:&#x2F;&#x2F;go.dev&#x2F;doc</a>` +
+		` and <A href="https://example.com/post">the post</A>.`
+
+	second := newComment(3, 1)
+	second.Text = `<p>Also <a href="https://go.dev/doc">docs</a> and <a href="https://github.com">GitHub</a>`
+
+	tree := &hn.ThreadNode{Item: root, Children: []*hn.ThreadNode{{Item: first}, {Item: second}}}
+
+	want := []hn.Link{
+		{ItemID: 1, URL: "https://example.com/post"},
+		{ItemID: 2, URL: "https://go.dev/doc"},
+		{ItemID: 2, URL: "https://example.com/post"},
+		{ItemID: 3, URL: "https://go.dev/doc"},
+		{ItemID: 3, URL: "https://github.com"},
+	}
+
+	if got := hn.ExtractLinks(tree, false); !slices.Equal(got, want) {
+		t.Errorf("ExtractLinks(unique = false) = %v, want %v", got, want)
+	}
+
+	want = []hn.Link{want[0], want[1], want[4]}
+
+	if got := hn.ExtractLinks(tree, true); !slices.Equal(got, want) {
+		t.Errorf("ExtractLinks(unique = true) = %v, want %v", got, want)
+	}
+}
+
+func TestExtractLinksNonASCII(t *testing.T) {
+	// Lowercasing "Ⱥ" makes it longer, so the offsets of the lowercase text don't match the original text.
+	for _, n := range []int{1, 20, 100} {
+		item := newComment(1, 0)
+		item.Text = strings.Repeat("Ⱥ", n) + `<a href="http://x.com">x</a> ünïcödé <a href="http://y.com">y</a>`
+
+		got := hn.ExtractLinks(&hn.ThreadNode{Item: item}, false)
+		want := []hn.Link{{ItemID: 1, URL: "http://x.com"}, {ItemID: 1, URL: "http://y.com"}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("ExtractLinks(%d runes before the anchor) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestExtractLinksMalformed(t *testing.T) {
+	item := newComment(1, 0)
+	item.Text = `<abbr title="x">a</abbr> <a>no href</a> a < b <a href="http://x.com"`
+
+	if got := hn.ExtractLinks(&hn.ThreadNode{Item: item}, false); len(got) != 0 {
+		t.Errorf("ExtractLinks() = %v, want no links", got)
+	}
+
+	if got := hn.ExtractLinks(nil, false); got != nil {
+		t.Errorf("ExtractLinks(nil) = %v, want nil", got)
+	}
+}
+
+func TestExtractLinksEscaped(t *testing.T) {
+	// The href is decoded once: "&amp;amp;" is the escaped text "&amp;" in the URL, not "&".
+	// The anchor typed by the user is escaped, so it's not a link.
+	comment := newComment(1, 0)
+	comment.Text = `<a href="https:&#x2F;&#x2F;example.com&#x2F;?q=a&amp;amp;b">x</a>` +
+		` try &lt;a href=&quot;http:&#x2F;&#x2F;typed.com&quot;&gt;`
+
+	want := []hn.Link{{ItemID: 1, URL: "https://example.com/?q=a&amp;b"}}
+
+	tests := []struct {
+		name string
+		opts []hn.Option
+	}{
+		{"unescaped", nil},
+		{"raw text", []hn.Option{hn.WithRawText()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &fixture{items: itemsOf(comment)}, tt.opts...)
+
+			item, err := client.Items.Get(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Get(1) error = %v", err)
+			}
+
+			if got := hn.ExtractLinks(&hn.ThreadNode{Item: item}, false); !slices.Equal(got, want) {
+				t.Errorf("ExtractLinks() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"host", "https://github.com/golang/go", "github.com"},
+		{"www prefix", "https://www.example.com/a", "example.com"},
+		{"port", "http://localhost:8080/", "localhost"},
+		{"subdomain", "https://blog.golang.org", "blog.golang.org"},
+		{"no url", "", ""},
+		{"invalid url", "http://[::1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newStory(1, 1)
+			item.URL = tt.url
+
+			job := newItem(2, hn.JobType)
+			job.URL = tt.url
+
+			got := []string{item.Domain(), hn.ToStory(item).Domain(), hn.ToJob(job).Domain()}
+
+			for _, domain := range got {
+				if domain != tt.want {
+					t.Errorf("Domain() of %q = %q, want %q", tt.url, domain, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPermalink(t *testing.T) {
+	t.Cleanup(func() { hn.SetSiteURL("https://news.ycombinator.com") })
+
+	tests := []struct {
+		name string
+		site string
+		link func() string
+		want string
+	}{
+		{"item", "", func() string { return newStory(8863, 1).Permalink() }, "https://news.ycombinator.com/item?id=8863"},
+		{"story", "", func() string { return hn.ToStory(newStory(1, 1)).Permalink() }, "https://news.ycombinator.com/item?id=1"},
+		{"user", "", func() string { return hn.User{ID: "pg"}.Permalink() }, "https://news.ycombinator.com/user?id=pg"},
+		{"escaped user", "", func() string { return hn.User{ID: "a b&c"}.Permalink() }, "https://news.ycombinator.com/user?id=a+b%26c"},
+		{"site url", "https://hn.example.com/", func() string { return newStory(1, 1).Permalink() }, "https://hn.example.com/item?id=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hn.SetSiteURL(cmp.Or(tt.site, "https://news.ycombinator.com"))
+
+			if got := tt.link(); got != tt.want {
+				t.Errorf("Permalink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTextPostIsLink(t *testing.T) {
+	item := func(itemType, url, text string) hn.Item {
+		item := newItem(1, itemType)
+		item.URL, item.Text = url, text
+
+		return item
+	}
+
+	tests := []struct {
+		name     string
+		item     hn.Item
+		textPost bool
+		link     bool
+	}{
+		{"link story", item(hn.StoryType, "https://example.com", ""), false, true},
+		{"text story", item(hn.StoryType, "", "Hello"), true, false},
+		{"link story with text", item(hn.StoryType, "https://example.com", "Hello"), false, true},
+		{"empty story", item(hn.StoryType, "", ""), false, false},
+		{"ask", item(hn.AskType, "", "Question?"), true, false},
+		{"ask without text", item(hn.AskType, "", ""), true, false},
+		{"job with url", item(hn.JobType, "https://example.com/jobs", ""), false, true},
+		{"job with text", item(hn.JobType, "", "We're hiring"), false, false},
+		{"comment", item(hn.CommentType, "", "Reply"), false, false},
+		{"poll", item(hn.PollType, "", "Vote"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.IsTextPost(); got != tt.textPost {
+				t.Errorf("Item.IsTextPost() = %v, want %v", got, tt.textPost)
+			}
+
+			if got := tt.item.IsLink(); got != tt.link {
+				t.Errorf("Item.IsLink() = %v, want %v", got, tt.link)
+			}
+
+			// The methods of the specific types agree with the methods of Item.
+			switch tt.item.Type {
+			case hn.StoryType:
+				story := hn.ToStory(tt.item)
+				if story.IsTextPost() != tt.textPost || story.IsLink() != tt.link {
+					t.Errorf("Story.IsTextPost(), Story.IsLink() = %v, %v, want %v, %v", story.IsTextPost(), story.IsLink(), tt.textPost, tt.link)
+				}
+			case hn.AskType:
+				if got := hn.ToAsk(tt.item).IsTextPost(); got != tt.textPost {
+					t.Errorf("Ask.IsTextPost() = %v, want %v", got, tt.textPost)
+				}
+			case hn.JobType:
+				if got := hn.ToJob(tt.item).IsLink(); got != tt.link {
+					t.Errorf("Job.IsLink() = %v, want %v", got, tt.link)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package hn_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestNDJSON(t *testing.T) {
+	story := newStory(1, 10, 2)
+	story.By, story.URL, story.Descendants = "pg", "https://example.com", 1
+	story.Time = hn.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	comment := newComment(2, 1)
+	comment.Text = "a &lt; b\nc"
+
+	poll := newItem(3, hn.PollType)
+	poll.Parts = []uint{4, 5}
+
+	deleted := newItem(6, hn.CommentType)
+	deleted.Deleted = true
+
+	tests := []struct {
+		name  string
+		items []hn.Item
+		lines int
+	}{
+		{"mixed", []hn.Item{story, comment, poll, deleted}, 4},
+		{"single", []hn.Item{story}, 1},
+		{"empty", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := hn.WriteNDJSON(&buf, tt.items); err != nil {
+				t.Fatalf("WriteNDJSON() error = %v", err)
+			}
+
+			if got := strings.Count(buf.String(), "\n"); got != tt.lines {
+				t.Errorf("WriteNDJSON() wrote %d lines, want %d", got, tt.lines)
+			}
+
+			var got []hn.Item
+
+			for item, err := range hn.ReadNDJSON(&buf) {
+				if err != nil {
+					t.Fatalf("ReadNDJSON() error = %v", err)
+				}
+
+				got = append(got, item)
+			}
+
+			if !reflect.DeepEqual(got, tt.items) {
+				t.Errorf("ReadNDJSON() = %+v, want %+v", got, tt.items)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := hn.WriteNDJSON(&buf, []hn.Item{story}); err != nil {
+		t.Fatalf("WriteNDJSON() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"time":1714564800`) {
+		t.Errorf("WriteNDJSON() = %s, want the time in Unix seconds", buf.String())
+	}
+}
+
+func TestReadNDJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []uint
+		wantErr bool
+	}{
+		{"blank lines", "{\"id\":1}\n\n{\"id\":2}\n", []uint{1, 2}, false},
+		{"malformed line", "{\"id\":1}\n{\"id\":\n{\"id\":3}\n", []uint{1}, true},
+		{"wrong type", "{\"id\":1}\n{\"id\":2,\"kids\":\"3\"}\n", []uint{1}, true},
+		{"no trailing newline", "{\"id\":1}", []uint{1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got []uint
+				err error
+			)
+
+			for item, e := range hn.ReadNDJSON(strings.NewReader(tt.input)) {
+				if e != nil {
+					if err != nil {
+						t.Fatal("ReadNDJSON() continued after an error")
+					}
+
+					err = e
+					continue
+				}
+
+				got = append(got, item.ID)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadNDJSON() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadNDJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteNDJSONError(t *testing.T) {
+	if err := hn.WriteNDJSON(failingWriter{}, []hn.Item{newStory(1, 1)}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("WriteNDJSON() error = %v, want the error of the writer", err)
+	}
+}
@@ -0,0 +1,323 @@
+package hn
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// Option configures optional behavior of a Client.
+type Option func(*options)
+
+// options contains the settings of a Client that can be changed with an Option.
+type options struct {
+	skipDeadDeleted bool
+	defaultFilter   func(Item) bool
+	rawText         bool
+	cacheSize       int
+	cacheTTL        time.Duration
+	requestHook     func(RequestInfo)
+	timeout         *time.Duration
+	transport       http.RoundTripper
+	baseURL         string
+	requestTimeout  time.Duration
+	progress        func(done, total int)
+	retryAttempts   int
+	backoff         *backoff
+	strictTypes     bool
+	conditional     *conditionalCache
+	sem             *semaphore.Weighted
+	retryBudget     *retryBudget
+	maxBodyBytes    int64
+	pool            *PoolConfig
+	batchWindow     time.Duration
+	batchSize       int
+	poolSize        int
+	userAgent       string
+	workers         *int
+	limiter         *rateLimiter
+}
+
+// newOptions returns the default options with opts applied.
+func newOptions(opts ...Option) *options {
+	o := &options{backoff: defaultBackoff, maxBodyBytes: defaultMaxResponseBytes}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithSkipDeadDeleted excludes deleted and dead items from the results of list operations.
+// The exclusion is applied in addition to the filter passed to the list method, so an item
+// is returned only if it's alive and matches that filter. The deleted and dead replies are also left out
+// of the threads (see ItemService.Thread), along with their own replies.
+func WithSkipDeadDeleted() Option {
+	return func(o *options) {
+		o.skipDeadDeleted = true
+	}
+}
+
+// WithDefaultFilter sets a filter that is applied to the results of all list operations
+// (e.g., LiveService.TopList or UserService.Items). The default filter is combined with
+// the filter passed to the list method, so an item is returned only if it matches both filters.
+func WithDefaultFilter(filter func(Item) bool) Option {
+	return func(o *options) {
+		o.defaultFilter = filter
+	}
+}
+
+// WithRawText disables decoding of HTML entities in the text fields of the fetched items and users,
+// so the text is returned exactly as it's stored on Hacker News.
+func WithRawText() Option {
+	return func(o *options) {
+		o.rawText = true
+	}
+}
+
+// WithItemCache enables an in-memory LRU cache for the items fetched by ItemService.Get.
+// The cache holds up to size items, and each item expires after ttl (a ttl of 0 means items never expire).
+// A size of 0 or less disables the cache.
+func WithItemCache(size int, ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheSize = size
+		o.cacheTTL = ttl
+	}
+}
+
+// WithRequestHook sets a hook that is called after each HTTP request sent by the client,
+// e.g., for logging or metrics. The hook can be called from multiple goroutines at once.
+func WithRequestHook(hook func(RequestInfo)) Option {
+	return func(o *options) {
+		o.requestHook = hook
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP requests sent by the client, overriding the timeout
+// of the default client or the client passed to NewClient (which is not modified).
+// A timeout of 0 means no timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = &d
+	}
+}
+
+// WithTransport sets the transport of the HTTP client, overriding the transport
+// of the default client or the client passed to NewClient (which is not modified).
+// Use DefaultTransport to wrap the transport of the default client:
+//
+//	hn.NewClient(nil, hn.WithTransport(&tracingTransport{next: hn.DefaultTransport()}))
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = rt
+	}
+}
+
+// WithBaseURL sets the base URL of the Hacker News API (e.g., for a mirror or a test server).
+// The default value is "https://hacker-news.firebaseio.com/v0".
+func WithBaseURL(u string) Option {
+	return func(o *options) {
+		o.baseURL = strings.TrimSuffix(u, "/")
+	}
+}
+
+// WithPerRequestTimeout sets the timeout of each individual request sent by the client,
+// separately from the deadline of the context passed to the client methods.
+// For example, each item fetched by ItemService.List gets its own timeout, so a single slow item
+// doesn't hold a worker for the whole deadline of the list. The deadline of the context still applies if it's earlier.
+func WithPerRequestTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.requestTimeout = d
+	}
+}
+
+// WithProgress sets a callback that reports the progress of the batch operations (e.g., ItemService.List)
+// each time an item is fetched, with the number of completed items and the total number of items.
+// The calls of the callback are serialized, but it should return quickly to not slow down the batch.
+func WithProgress(fn func(done, total int)) Option {
+	return func(o *options) {
+		o.progress = fn
+	}
+}
+
+// WithRetry enables retries of the failed requests, so each request is sent up to attempts times.
+// Requests are retried after network errors and responses with status 429 or 5xx, with an exponential
+// backoff of 100ms to 5s with full jitter between the attempts, unless it's changed with WithBackoff.
+func WithRetry(attempts int) Option {
+	return func(o *options) {
+		o.retryAttempts = attempts
+	}
+}
+
+// WithBackoff sets the backoff between the attempts of the retried requests (see WithRetry).
+// The delay after the n-th attempt (starting from 0) is min(base * 2^n, max), reduced by a random part
+// of up to jitter (from 0 to 1) of the delay, e.g., a jitter of 1 makes the delay random from 0 to the full value.
+// The delay is interrupted if the context of the request is canceled.
+func WithBackoff(base, maxDelay time.Duration, jitter float64) Option {
+	return func(o *options) {
+		o.backoff = &backoff{base: base, max: maxDelay, jitter: min(max(jitter, 0), 1)}
+	}
+}
+
+// WithStrictTypes makes ItemService return an error wrapping ErrUnknownType for the items
+// whose type is empty or is not one of the known types (e.g., StoryType), instead of returning them as is.
+func WithStrictTypes() Option {
+	return func(o *options) {
+		o.strictTypes = true
+	}
+}
+
+// WithConditionalCache enables conditional requests: the responses with an ETag or Last-Modified header
+// are cached, and the following requests to the same URL are sent with the If-None-Match and If-Modified-Since
+// headers, so the cached response is used if the server replies with 304 Not Modified.
+// The responses without these headers are not cached. Up to 1000 responses are kept:
+// when the cache is full, the least recently used response is evicted.
+func WithConditionalCache() Option {
+	return func(o *options) {
+		o.conditional = newConditionalCache(conditionalCacheSize)
+	}
+}
+
+// WithMaxConcurrency limits the number of requests sent by the client at once to n, across all concurrent calls
+// of the client methods (e.g., several ItemService.List calls at once). The limit of workers set with SetMaxWorkers
+// still applies to each call. A value of n less than 1 means no limit.
+func WithMaxConcurrency(n int) Option {
+	return func(o *options) {
+		o.sem = nil
+		if n > 0 {
+			o.sem = semaphore.NewWeighted(int64(n))
+		}
+	}
+}
+
+// WithRetryBudget limits the rate of retries (see WithRetry) to avoid retry storms during outages.
+// Each successful request adds ratio to the budget (e.g., 0.1 allows one retry per ten successful requests),
+// each retry takes 1 from it, and the budget can hold up to 10. When the budget is exhausted,
+// the failed requests are not retried until the successful requests replenish it.
+func WithRetryBudget(ratio float64) Option {
+	return func(o *options) {
+		o.retryBudget = newRetryBudget(ratio)
+	}
+}
+
+// WithMaxResponseBytes limits the size of the response bodies to n bytes (16 MiB by default),
+// protecting against endpoints returning huge responses. Requests with larger responses fail
+// with an error wrapping ErrResponseTooLarge. A value of 0 or less means there is no limit.
+func WithMaxResponseBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBodyBytes = n
+	}
+}
+
+// PoolConfig contains the connection pool settings of an *http.Transport (see WithConnectionPool).
+// The fields left as 0 keep the value of the transport, so only the settings that are set are changed.
+type PoolConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+}
+
+// WithConnectionPool changes the connection pool settings of the transport of the client,
+// keeping its other settings (including the pool settings left as 0 in config). The settings are applied
+// to a copy of the transport passed with WithTransport, or the transport of the HTTP client passed to NewClient
+// (e.g., the default client), and have no effect if the transport is not an *http.Transport.
+// It panics if any of the settings is negative.
+func WithConnectionPool(config PoolConfig) Option {
+	if config.MaxIdleConns < 0 || config.MaxIdleConnsPerHost < 0 || config.MaxConnsPerHost < 0 || config.IdleConnTimeout < 0 {
+		panic(fmt.Sprintf("hn: invalid connection pool settings: %+v", config))
+	}
+
+	return func(o *options) {
+		o.pool = &config
+	}
+}
+
+// transport returns a copy of the transport with the pool settings applied,
+// or the transport itself if it's not an *http.Transport. A nil transport means http.DefaultTransport.
+func (c *PoolConfig) transport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+
+	t = t.Clone()
+	t.MaxIdleConns = cmp.Or(c.MaxIdleConns, t.MaxIdleConns)
+	t.MaxIdleConnsPerHost = cmp.Or(c.MaxIdleConnsPerHost, t.MaxIdleConnsPerHost)
+	t.MaxConnsPerHost = cmp.Or(c.MaxConnsPerHost, t.MaxConnsPerHost)
+	t.IdleConnTimeout = cmp.Or(c.IdleConnTimeout, t.IdleConnTimeout)
+
+	return t
+}
+
+// WithBatchWindow makes ItemService coalesce the requests of Get (including the requests of List, Thread, etc.)
+// into waves, smoothing the request rate of large operations. The requests arriving within the window d
+// are queued and then sent in waves of up to size requests at once, with a delay of d between the waves.
+// Each caller still gets its own result. A size of 0 or less disables the batching.
+func WithBatchWindow(d time.Duration, size int) Option {
+	return func(o *options) {
+		o.batchWindow = d
+		o.batchSize = size
+	}
+}
+
+// WithWorkerPool makes ItemService run the fetches of all its operations (e.g., List or Thread)
+// on a pool of up to n workers shared by the concurrent calls, instead of starting separate workers for each call.
+// The workers are reused while there are queued fetches, so the total concurrency of the operations is
+// limited by n, and the limit set with SetMaxWorkers doesn't apply. A value of n less than 1 disables the pool.
+func WithWorkerPool(n int) Option {
+	return func(o *options) {
+		o.poolSize = n
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests sent by the client.
+// The default value is "hn-client/<version>". Requests passed to Do with their own User-Agent are not changed.
+func WithUserAgent(ua string) Option {
+	return func(o *options) {
+		o.userAgent = ua
+	}
+}
+
+// WithMaxWorkers sets the maximum number of workers for multiple item fetch operations of the client,
+// like SetMaxWorkers, but only for this client. A value of 0 or less means there is no limit.
+func WithMaxWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = &n
+	}
+}
+
+// WithRateLimit limits the rate of the requests sent by the client to perSecond requests per second,
+// spacing them evenly across all concurrent calls of the client methods. Each request waits for its turn
+// (or until its context is canceled) before it's sent. A value of 0 or less means no limit.
+func WithRateLimit(perSecond float64) Option {
+	return func(o *options) {
+		o.limiter = nil
+		if perSecond > 0 {
+			o.limiter = newRateLimiter(perSecond)
+		}
+	}
+}
+
+// workerLimit returns the worker limit of the client (see WithMaxWorkers), or the limit set with SetMaxWorkers.
+// It returns -1 if there is no limit, since an errgroup.Group with a limit of 0 never starts any goroutine.
+func (o *options) workerLimit() int {
+	n := maxWorkers
+	if o.workers != nil {
+		n = *o.workers
+	}
+
+	if n <= 0 {
+		return -1
+	}
+
+	return n
+}
@@ -0,0 +1,323 @@
+package hn
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithConnectionPool(t *testing.T) {
+	base := &http.Transport{MaxIdleConns: 50, MaxIdleConnsPerHost: 10, MaxConnsPerHost: 20, IdleConnTimeout: time.Minute}
+
+	client := NewClient(&http.Client{Transport: base}, WithConnectionPool(PoolConfig{MaxConnsPerHost: 5}))
+
+	got, ok := client.Items.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", client.Items.client.Transport)
+	}
+
+	if got == base {
+		t.Fatal("the transport of the HTTP client was changed, want a copy")
+	}
+
+	// Only the settings that are set replace the settings of the transport.
+	if got.MaxConnsPerHost != 5 || got.MaxIdleConns != 50 || got.MaxIdleConnsPerHost != 10 || got.IdleConnTimeout != time.Minute {
+		t.Errorf("pool settings = %d, %d, %d, %v, want 50, 10, 5, 1m0s",
+			got.MaxIdleConns, got.MaxIdleConnsPerHost, got.MaxConnsPerHost, got.IdleConnTimeout)
+	}
+
+	if base.MaxConnsPerHost != 20 {
+		t.Errorf("MaxConnsPerHost of the original transport = %d, want 20", base.MaxConnsPerHost)
+	}
+}
+
+func TestWithConnectionPoolOtherTransport(t *testing.T) {
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, http.ErrNotSupported
+	})
+
+	client := NewClient(nil, WithTransport(rt), WithConnectionPool(PoolConfig{MaxConnsPerHost: 5}))
+
+	if _, ok := client.Items.client.Transport.(roundTripperFunc); !ok {
+		t.Errorf("transport = %T, want the transport passed with WithTransport", client.Items.client.Transport)
+	}
+}
+
+func TestWithConnectionPoolNegative(t *testing.T) {
+	for _, config := range []PoolConfig{{MaxIdleConns: -1}, {MaxIdleConnsPerHost: -1}, {MaxConnsPerHost: -1}, {IdleConnTimeout: -time.Second}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("WithConnectionPool(%+v) didn't panic", config)
+				}
+			}()
+
+			WithConnectionPool(config)
+		}()
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	custom := &http.Client{Timeout: 10 * time.Second}
+
+	tests := []struct {
+		name   string
+		client *http.Client
+		opts   []Option
+		want   time.Duration
+	}{
+		{"default client", nil, nil, defaultTimeout},
+		{"custom client", custom, nil, 10 * time.Second},
+		{"option", nil, []Option{WithTimeout(5 * time.Second)}, 5 * time.Second},
+		{"option with custom client", custom, []Option{WithTimeout(time.Second)}, time.Second},
+		{"no timeout", nil, []Option{WithTimeout(0)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(tt.client, tt.opts...)
+
+			if got := client.Items.client.Timeout; got != tt.want {
+				t.Errorf("timeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The clients passed to NewClient are copied, not changed.
+	if custom.Timeout != 10*time.Second || defaultClient.Timeout != defaultTimeout {
+		t.Errorf("timeouts of the original clients = %v, %v, want %v, %v",
+			custom.Timeout, defaultClient.Timeout, 10*time.Second, defaultTimeout)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	var calls int
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return itemResponse(r), nil
+	})
+
+	custom := &http.Client{Transport: http.DefaultTransport}
+
+	tests := []struct {
+		name   string
+		client *http.Client
+	}{
+		{"default client", nil},
+		{"custom client", custom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+
+			client := NewClient(tt.client, WithTransport(rt))
+
+			if _, err := client.Items.Get(context.Background(), 1); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if calls != 1 {
+				t.Errorf("transport called %d times, want 1", calls)
+			}
+		})
+	}
+
+	if custom.Transport != http.DefaultTransport {
+		t.Errorf("transport of the custom client = %T, want the original transport", custom.Transport)
+	}
+}
+
+func TestDefaultTransport(t *testing.T) {
+	transport := DefaultTransport()
+
+	if transport == defaultClient.Transport {
+		t.Fatal("DefaultTransport() returned the transport of the default client, want a copy")
+	}
+
+	if transport.MaxConnsPerHost != defaultMaxWorkers || transport.MaxIdleConns != 100 {
+		t.Errorf("MaxConnsPerHost, MaxIdleConns = %d, %d, want %d, 100", transport.MaxConnsPerHost, transport.MaxIdleConns, defaultMaxWorkers)
+	}
+}
+
+func TestWithPerRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		deadline time.Duration
+		slow     bool
+		want     error
+	}{
+		{"fast request", 10 * time.Millisecond, 0, false, nil},
+		{"slow request", 10 * time.Millisecond, 0, true, context.DeadlineExceeded},
+		{"earlier per-request timeout", 10 * time.Millisecond, time.Hour, true, context.DeadlineExceeded},
+		{"earlier context deadline", time.Hour, 10 * time.Millisecond, true, context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(nil, WithPerRequestTimeout(tt.timeout), WithTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if tt.slow {
+					<-r.Context().Done()
+					return nil, r.Context().Err()
+				}
+
+				return itemResponse(r), nil
+			})))
+
+			ctx := context.Background()
+			if tt.deadline > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			start := time.Now()
+
+			_, err := client.Items.Get(ctx, 1)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Get() error = %v, want %v", err, tt.want)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Get() returned after %v, want the earlier deadline to apply", elapsed)
+			}
+		})
+	}
+}
+
+func TestWorkerLimit(t *testing.T) {
+	tests := []struct {
+		opts []Option
+		want int
+	}{
+		{nil, defaultMaxWorkers},
+		{[]Option{WithMaxWorkers(5)}, 5},
+		{[]Option{WithMaxWorkers(0)}, -1},
+		{[]Option{WithMaxWorkers(-3)}, -1},
+	}
+
+	for _, tt := range tests {
+		if got := newOptions(tt.opts...).workerLimit(); got != tt.want {
+			t.Errorf("workerLimit() = %d, want %d", got, tt.want)
+		}
+	}
+}
+
+func TestWithMaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+	}{
+		{"one", 1},
+		{"several", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu        sync.Mutex
+				cur, peak int
+			)
+
+			rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				mu.Lock()
+				cur++
+				peak = max(peak, cur)
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				cur--
+				mu.Unlock()
+
+				return itemResponse(r), nil
+			})
+
+			client := NewClient(nil, WithTransport(rt), WithMaxConcurrency(tt.limit))
+
+			// The calls fetch different items, so their requests are not shared.
+			var wg sync.WaitGroup
+
+			for call := range uint(4) {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					ids := []uint{call*10 + 1, call*10 + 2, call*10 + 3, call*10 + 4, call*10 + 5}
+					if _, err := client.Items.List(context.Background(), ids, nil); err != nil {
+						t.Errorf("List() error = %v", err)
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			if peak > tt.limit {
+				t.Errorf("%d requests in flight, want at most %d", peak, tt.limit)
+			}
+		})
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(client *Client, ids []uint)
+	}{
+		{"list", func(client *Client, ids []uint) {
+			_, _ = client.Items.List(context.Background(), ids, nil)
+		}},
+		{"list partial", func(client *Client, ids []uint) {
+			_, _ = client.Items.ListPartial(context.Background(), ids, nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]int
+
+			client := NewClient(nil, WithTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return itemResponse(r), nil
+			})), WithProgress(func(done, total int) {
+				got = append(got, [2]int{done, total})
+			}))
+
+			tt.run(client, []uint{1, 2, 3, 4, 5})
+
+			want := [][2]int{{1, 5}, {2, 5}, {3, 5}, {4, 5}, {5, 5}}
+			if !slices.Equal(got, want) {
+				t.Errorf("progress = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// itemResponse returns a response with a story whose ID is taken from the URL of the item request.
+func itemResponse(r *http.Request) *http.Response {
+	id := strings.TrimSuffix(path.Base(r.URL.Path), ".json")
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"id":` + id + `,"type":"story"}`)),
+		Request:    r,
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
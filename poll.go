@@ -0,0 +1,62 @@
+package hn
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// PollResults contains a poll with the results of its options.
+type PollResults struct {
+	Poll    Poll
+	Options []PollOptionResult // options sorted by score in descending order
+	Votes   int                // total number of votes of all options
+}
+
+// PollOptionResult is an option of a poll with its share of the votes.
+type PollOptionResult struct {
+	PollOption
+
+	Percent float64 // percentage of the total votes of the poll, from 0 to 100
+}
+
+// PollResults returns the poll with the specified ID along with its options, fetched concurrently.
+// The options are sorted by score in descending order (keeping the original order in case of a tie),
+// and the options that are not found are skipped. If the poll has no votes, each option has 0 percent.
+func (s *ItemService) PollResults(ctx context.Context, pollID uint) (PollResults, error) {
+	poll, err := GetAs[Poll](ctx, s, pollID)
+	if err != nil {
+		return PollResults{}, err
+	}
+
+	parts, err := s.getEach(ctx, poll.Parts)
+	if err != nil {
+		return PollResults{}, err
+	}
+
+	results := PollResults{
+		Poll:    poll,
+		Options: make([]PollOptionResult, 0, len(parts)),
+	}
+
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+
+		results.Options = append(results.Options, PollOptionResult{PollOption: ToPollOption(*part)})
+		results.Votes += part.Score
+	}
+
+	slices.SortStableFunc(results.Options, func(a, b PollOptionResult) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	if results.Votes > 0 {
+		for i := range results.Options {
+			results.Options[i].Percent = float64(results.Options[i].Score) / float64(results.Votes) * 100
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,86 @@
+package hn_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func pollOf(id uint, parts ...uint) hn.Item {
+	poll := newItem(id, hn.PollType)
+	poll.Parts = parts
+
+	return poll
+}
+
+func optionOf(id, poll uint, score int) hn.Item {
+	option := newItem(id, hn.PollOptionType)
+	option.Poll, option.Score = poll, score
+
+	return option
+}
+
+func TestPollResults(t *testing.T) {
+	// The option 9 of the poll 1 is missing.
+	f := &fixture{items: itemsOf(
+		pollOf(1, 2, 3, 4, 5, 9),
+		optionOf(2, 1, 10),
+		optionOf(3, 1, 30),
+		optionOf(4, 1, 0),
+		optionOf(5, 1, 10),
+		pollOf(6, 7, 8),
+		optionOf(7, 6, 0),
+		optionOf(8, 6, 0),
+		pollOf(10),
+		newStory(11, 1),
+	)}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name    string
+		id      uint
+		options []uint
+		percent []float64
+		votes   int
+	}{
+		{"multiple options", 1, []uint{3, 2, 5, 4}, []float64{60, 20, 20, 0}, 50},
+		{"no votes", 6, []uint{7, 8}, []float64{0, 0}, 0},
+		{"no options", 10, []uint{}, []float64{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := client.Items.PollResults(context.Background(), tt.id)
+			if err != nil {
+				t.Fatalf("PollResults(%d) error = %v", tt.id, err)
+			}
+
+			if results.Poll.ID != tt.id || results.Votes != tt.votes {
+				t.Errorf("PollResults(%d) = poll %d with %d votes, want %d votes", tt.id, results.Poll.ID, results.Votes, tt.votes)
+			}
+
+			ids := make([]uint, 0, len(results.Options))
+			percent := make([]float64, 0, len(results.Options))
+
+			for _, option := range results.Options {
+				ids = append(ids, option.ID)
+				percent = append(percent, option.Percent)
+			}
+
+			if !slices.Equal(ids, tt.options) || !slices.Equal(percent, tt.percent) {
+				t.Errorf("PollResults(%d) options = %v with %v percent, want %v with %v", tt.id, ids, percent, tt.options, tt.percent)
+			}
+		})
+	}
+
+	if _, err := client.Items.PollResults(context.Background(), 11); err == nil {
+		t.Error("PollResults() of a story error = nil, want an error")
+	}
+
+	if _, err := client.Items.PollResults(context.Background(), 12); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("PollResults() of a missing poll error = %v, want ErrNotFound", err)
+	}
+}
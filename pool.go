@@ -0,0 +1,104 @@
+package hn
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// workerPool runs tasks on up to size goroutines shared by all the operations of an ItemService
+// (see WithWorkerPool). The goroutines are started when the tasks are submitted, reused
+// for the queued tasks, and stopped when the queue is empty, so an idle pool has no goroutines.
+type workerPool struct {
+	size int
+
+	mu      sync.Mutex
+	queue   []func()
+	workers int
+}
+
+func newWorkerPool(size int) *workerPool {
+	return &workerPool{size: size}
+}
+
+// submit queues the task, starting a new worker if the pool has fewer than size workers.
+func (p *workerPool) submit(task func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.queue = append(p.queue, task)
+
+	if p.workers < p.size {
+		p.workers++
+		go p.work()
+	}
+}
+
+// work runs the queued tasks until the queue is empty.
+func (p *workerPool) work() {
+	for {
+		p.mu.Lock()
+
+		if len(p.queue) == 0 {
+			p.workers--
+			p.mu.Unlock()
+
+			return
+		}
+
+		task := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+
+		p.mu.Unlock()
+
+		task()
+	}
+}
+
+// forEach calls fn concurrently for each index from 0 to n-1, bounded by the worker limit of the client,
+// or on the worker pool if it's enabled. It returns the first error returned by fn,
+// and the context passed to fn is canceled as soon as fn returns an error.
+func (s *ItemService) forEach(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	if s.pool == nil {
+		g, ctx := errgroup.WithContext(ctx)
+		g.SetLimit(s.opts.workerLimit())
+
+		for i := range n {
+			g.Go(func() error {
+				return fn(ctx, i)
+			})
+		}
+
+		return g.Wait()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+
+	wg.Add(n)
+
+	for i := range n {
+		s.pool.submit(func() {
+			defer wg.Done()
+
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return first
+}
@@ -0,0 +1,132 @@
+package hn_test
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestWorkerPoolLimit(t *testing.T) {
+	const size = 3
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, client *hn.Client, ids []uint) error
+	}{
+		{"list", func(ctx context.Context, client *hn.Client, ids []uint) error {
+			_, err := client.Items.List(ctx, ids, nil)
+			return err
+		}},
+		{"stream", func(ctx context.Context, client *hn.Client, ids []uint) error {
+			for _, err := range client.Items.Stream(ctx, ids, nil) {
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				items []hn.Item
+				probe inFlight
+			)
+
+			for id := range uint(40) {
+				items = append(items, newStory(id+1, 1))
+			}
+
+			f := &fixture{items: itemsOf(items...), handler: probe.handler(5 * time.Millisecond)}
+			client := newTestClient(t, f, hn.WithWorkerPool(size), hn.WithMaxWorkers(0))
+
+			// The calls fetch different items, so the requests are not shared by flightGroup.
+			var wg sync.WaitGroup
+
+			for call := range uint(4) {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					if err := tt.run(context.Background(), client, idRange(call*10+1, call*10+10)); err != nil {
+						t.Errorf("error = %v", err)
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			if n := probe.max(); n > size {
+				t.Errorf("%d requests in flight, want at most %d", n, size)
+			}
+
+			if n := f.total(); n != 40 {
+				t.Errorf("got %d requests, want 40", n)
+			}
+		})
+	}
+}
+
+func TestWorkerPoolNestedList(t *testing.T) {
+	// Each of the stories 1 to 5 has the comments 101 to 103.
+	var items []hn.Item
+
+	for id := uint(1); id <= 5; id++ {
+		items = append(items, newStory(id, 1, 101, 102, 103))
+	}
+
+	items = append(items, newComment(101, 1), newComment(102, 1), newComment(103, 1))
+
+	tests := []struct {
+		name   string
+		stream func(ctx context.Context, client *hn.Client) iter.Seq2[hn.Item, error]
+	}{
+		{"stream", func(ctx context.Context, client *hn.Client) iter.Seq2[hn.Item, error] {
+			return client.Items.Stream(ctx, idRange(1, 5), nil)
+		}},
+		{"user stream", func(ctx context.Context, client *hn.Client) iter.Seq2[hn.Item, error] {
+			return client.Users.Stream(ctx, "pg", nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fixture{items: itemsOf(items...), users: map[string]hn.User{"pg": {ID: "pg", Submitted: idRange(1, 5)}}}
+			client := newTestClient(t, f, hn.WithWorkerPool(2))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			var n int
+
+			// The fetches of the nested calls run on the same pool as the fetches of the stream.
+			for story, err := range tt.stream(ctx, client) {
+				if err != nil {
+					t.Fatalf("Stream() error = %v", err)
+				}
+
+				kids, err := client.Items.List(ctx, story.Kids, nil)
+				if err != nil {
+					t.Fatalf("List() of the kids of %d error = %v", story.ID, err)
+				}
+
+				if len(kids) != 3 {
+					t.Errorf("List() of the kids of %d returned %d items, want 3", story.ID, len(kids))
+				}
+
+				n++
+			}
+
+			if n != 5 {
+				t.Errorf("Stream() yielded %d stories, want 5", n)
+			}
+		})
+	}
+}
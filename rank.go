@@ -0,0 +1,111 @@
+package hn
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// DefaultGravity is the gravity of the Hacker News front page ranking.
+const DefaultGravity = 1.8
+
+// Rank returns a copy of the stories sorted in descending order of the time-decayed score
+// used by the Hacker News front page: (score - 1) / (age + 2)^gravity, where age is the age of the story in hours at now.
+func Rank(items []Story, gravity float64, now time.Time) []Story {
+	ranked := slices.Clone(items)
+
+	SortBy(ranked, func(s Story) float64 {
+		return RankScore(s.Score, now.Sub(s.Time.Time), gravity)
+	}, Descending)
+
+	return ranked
+}
+
+// RankFrontPage is like Rank with the DefaultGravity.
+func RankFrontPage(items []Story, now time.Time) []Story {
+	return Rank(items, DefaultGravity, now)
+}
+
+// RankScore returns the time-decayed score of an item with the given score and age.
+// A negative age (e.g., because of clock skew) is treated as 0.
+func RankScore(score int, age time.Duration, gravity float64) float64 {
+	return float64(score-1) / math.Pow(max(age.Hours(), 0)+2, gravity)
+}
+
+// Normalization represents a method of normalizing the scores of items.
+// NormalizeScores panics if the method is neither NormalizeMinMax nor NormalizeZScore.
+type Normalization int
+
+const (
+	// NormalizeMinMax scales the scores to the range from 0 (the lowest score) to 1 (the highest score).
+	NormalizeMinMax Normalization = iota
+
+	// NormalizeZScore replaces the scores with their number of standard deviations from the mean score.
+	NormalizeZScore
+)
+
+// ScoredItem is an item along with its normalized score.
+type ScoredItem struct {
+	Item  Item
+	Score float64
+}
+
+// NormalizeScores returns the items with their scores normalized separately for each type of items,
+// so the items of different types (e.g., stories and asks) can be ranked together in a single feed.
+// The items are returned in their original order. If all items of a type have the same score
+// (e.g., jobs, which have no score), their normalized scores are 0.
+// It panics if the method is invalid.
+func NormalizeScores(items []Item, method Normalization) []ScoredItem {
+	if method != NormalizeMinMax && method != NormalizeZScore {
+		panic(fmt.Sprintf("hn: invalid normalization: %d", method))
+	}
+
+	type stats struct {
+		n, sum, sumSquares float64
+		min, max           int
+	}
+
+	types := make(map[string]*stats)
+
+	for _, item := range items {
+		st, ok := types[item.Type]
+		if !ok {
+			st = &stats{min: item.Score, max: item.Score}
+			types[item.Type] = st
+		}
+
+		score := float64(item.Score)
+
+		st.n++
+		st.sum += score
+		st.sumSquares += score * score
+		st.min, st.max = min(st.min, item.Score), max(st.max, item.Score)
+	}
+
+	scored := make([]ScoredItem, len(items))
+
+	for i, item := range items {
+		var (
+			st    = types[item.Type]
+			score = float64(item.Score)
+		)
+
+		scored[i].Item = item
+
+		switch method {
+		case NormalizeMinMax:
+			if st.max > st.min {
+				scored[i].Score = (score - float64(st.min)) / float64(st.max-st.min)
+			}
+		case NormalizeZScore:
+			mean := st.sum / st.n
+
+			if stddev := math.Sqrt(max(st.sumSquares/st.n-mean*mean, 0)); stddev > 0 {
+				scored[i].Score = (score - mean) / stddev
+			}
+		}
+	}
+
+	return scored
+}
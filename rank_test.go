@@ -0,0 +1,136 @@
+package hn_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestRankScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		score   int
+		age     time.Duration
+		gravity float64
+		want    float64
+	}{
+		{"single point", 1, 0, hn.DefaultGravity, 0},
+		{"new story", 3, 0, 1, 1},
+		{"negative age", 3, -time.Hour, 1, 1},
+		{"linear decay", 11, 3 * time.Hour, 1, 2},
+		{"quadratic decay", 5, 2 * time.Hour, 2, 0.25},
+		{"no decay", 5, 100 * time.Hour, 0, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hn.RankScore(tt.score, tt.age, tt.gravity); got != tt.want {
+				t.Errorf("RankScore(%d, %v, %v) = %v, want %v", tt.score, tt.age, tt.gravity, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	story := func(id uint, score int, age time.Duration) hn.Story {
+		item := newStory(id, score)
+		item.Time = hn.Timestamp{Time: now.Add(-age)}
+
+		return hn.ToStory(item)
+	}
+
+	stories := []hn.Story{
+		story(1, 100, 10*time.Hour),
+		story(2, 30, time.Hour),
+		story(3, 500, 48*time.Hour),
+		story(4, 10, 0),
+	}
+
+	tests := []struct {
+		name string
+		rank func([]hn.Story) []hn.Story
+		want []uint
+	}{
+		{"front page", func(s []hn.Story) []hn.Story { return hn.RankFrontPage(s, now) }, []uint{2, 4, 1, 3}},
+		{"default gravity", func(s []hn.Story) []hn.Story { return hn.Rank(s, hn.DefaultGravity, now) }, []uint{2, 4, 1, 3}},
+		{"no gravity", func(s []hn.Story) []hn.Story { return hn.Rank(s, 0, now) }, []uint{3, 1, 2, 4}},
+		{"high gravity", func(s []hn.Story) []hn.Story { return hn.Rank(s, 3, now) }, []uint{4, 2, 1, 3}},
+		{"empty", func([]hn.Story) []hn.Story { return hn.Rank(nil, hn.DefaultGravity, now) }, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hn.IDsOf(tt.rank(stories)); !slices.Equal(got, tt.want) {
+				t.Errorf("ranked = %v, want %v", got, tt.want)
+			}
+
+			if got := hn.IDsOf(stories); !slices.Equal(got, []uint{1, 2, 3, 4}) {
+				t.Errorf("stories = %v after ranking, want them unchanged", got)
+			}
+		})
+	}
+}
+
+func TestNormalizeScores(t *testing.T) {
+	ask := func(id uint, score int) hn.Item {
+		item := newItem(id, hn.AskType)
+		item.Score = score
+
+		return item
+	}
+
+	items := []hn.Item{
+		newStory(1, 10),
+		ask(2, 5),
+		newStory(3, 20),
+		newItem(4, hn.JobType),
+		ask(5, 15),
+		newStory(6, 30),
+		newItem(7, hn.JobType),
+	}
+
+	tests := []struct {
+		name   string
+		method hn.Normalization
+		items  []hn.Item
+		want   []float64
+	}{
+		{"min-max", hn.NormalizeMinMax, items, []float64{0, 0, 0.5, 0, 1, 1, 0}},
+		{"z-score", hn.NormalizeZScore, items, []float64{-math.Sqrt(1.5), -1, 0, 0, 1, math.Sqrt(1.5), 0}},
+		{"single item", hn.NormalizeZScore, items[:1], []float64{0}},
+		{"empty", hn.NormalizeMinMax, nil, []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scored := hn.NormalizeScores(tt.items, tt.method)
+
+			if len(scored) != len(tt.want) {
+				t.Fatalf("NormalizeScores() returned %d items, want %d", len(scored), len(tt.want))
+			}
+
+			for i, s := range scored {
+				if s.Item.ID != tt.items[i].ID {
+					t.Errorf("item %d = %d, want %d", i, s.Item.ID, tt.items[i].ID)
+				}
+
+				if math.Abs(s.Score-tt.want[i]) > 1e-9 {
+					t.Errorf("score of item %d = %v, want %v", s.Item.ID, s.Score, tt.want[i])
+				}
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NormalizeScores() with an invalid method didn't panic")
+		}
+	}()
+
+	hn.NormalizeScores(items, hn.Normalization(-1))
+}
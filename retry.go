@@ -0,0 +1,89 @@
+package hn
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// defaultBackoff is the backoff used for retries unless it's changed with WithBackoff.
+var defaultBackoff = &backoff{
+	base:   100 * time.Millisecond,
+	max:    5 * time.Second,
+	jitter: 1,
+}
+
+// backoff computes the delays between the attempts of a request.
+type backoff struct {
+	base   time.Duration
+	max    time.Duration
+	jitter float64
+
+	// random returns a number in [0.0, 1.0) and is replaced in tests for deterministic delays.
+	random func() float64
+}
+
+// delay returns the delay after the given attempt (starting from 0): min(base * 2^attempt, max),
+// reduced by a random part of up to jitter of the delay.
+func (b *backoff) delay(attempt int) time.Duration {
+	// The maximum is shifted instead of the base, so a large base or attempt can't overflow the delay.
+	d := b.max
+	if b.base <= b.max>>attempt {
+		d = b.base << attempt
+	}
+
+	random := b.random
+	if random == nil {
+		random = rand.Float64
+	}
+
+	return d - time.Duration(b.jitter*random()*float64(d))
+}
+
+// maxRetryTokens is the capacity of a retry budget, i.e., the number of retries allowed in a row
+// when the budget is full.
+const maxRetryTokens = 10
+
+// retryBudget is a concurrency-safe token bucket limiting the rate of retries.
+// Each successful request adds ratio tokens, and each retry takes one token, so under sustained
+// failure the retries stop once the budget is drained. All methods are safe to call on a nil budget,
+// which allows any number of retries.
+type retryBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	tokens float64
+}
+
+func newRetryBudget(ratio float64) *retryBudget {
+	return &retryBudget{ratio: ratio, tokens: maxRetryTokens}
+}
+
+// deposit adds the tokens for a successful request.
+func (b *retryBudget) deposit() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, maxRetryTokens)
+}
+
+// withdraw takes a token for a retry, reporting whether the retry is allowed.
+func (b *retryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
@@ -0,0 +1,35 @@
+package hn
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       backoff
+		attempt int
+		want    time.Duration
+	}{
+		{"first attempt", backoff{base: 100 * time.Millisecond, max: 5 * time.Second}, 0, 100 * time.Millisecond},
+		{"doubled", backoff{base: 100 * time.Millisecond, max: 5 * time.Second}, 3, 800 * time.Millisecond},
+		{"capped", backoff{base: 100 * time.Millisecond, max: 5 * time.Second}, 6, 5 * time.Second},
+		{"exactly max", backoff{base: time.Second, max: 4 * time.Second}, 2, 4 * time.Second},
+		{"large attempt", backoff{base: time.Millisecond, max: time.Minute}, 100, time.Minute},
+		{"large base", backoff{base: math.MaxInt64 / 3, max: math.MaxInt64}, 2, math.MaxInt64},
+		{"base above max", backoff{base: time.Hour, max: time.Second}, 0, time.Second},
+		{"with jitter", backoff{base: time.Second, max: time.Minute, jitter: 0.5}, 1, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.b.random = func() float64 { return 1 }
+
+			if got := tt.b.delay(tt.attempt); got != tt.want {
+				t.Errorf("delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
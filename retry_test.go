@@ -0,0 +1,99 @@
+package hn_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestRetry(t *testing.T) {
+	f := &fixture{items: itemsOf(newStory(1, 10))}
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		if f.count(r.URL.Path) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		}
+
+		return false
+	}
+
+	client := newTestClient(t, f, hn.WithRetry(3), hn.WithBackoff(time.Millisecond, 10*time.Millisecond, 0))
+
+	item, err := client.Items.Get(context.Background(), 1)
+	if err != nil || item.ID != 1 {
+		t.Fatalf("Get() = %v, %v, want item 1", item.ID, err)
+	}
+
+	if n := f.count("/item/1.json"); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+}
+
+func TestRetryClientError(t *testing.T) {
+	f := &fixture{}
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		w.WriteHeader(http.StatusBadRequest)
+		return true
+	}
+
+	client := newTestClient(t, f, hn.WithRetry(3), hn.WithBackoff(time.Millisecond, 10*time.Millisecond, 0))
+
+	if _, err := client.Items.Get(context.Background(), 1); err == nil {
+		t.Fatal("Get() error = nil, want an error")
+	}
+
+	// Client errors other than 429 are not retried.
+	if n := f.count("/item/1.json"); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	// The stories 100 and above are served, and all other items fail.
+	f := &fixture{items: itemsOf(newStory(100, 1), newStory(101, 1))}
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == "/item/100.json" || r.URL.Path == "/item/101.json" {
+			return false
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return true
+	}
+
+	client := newTestClient(t, f, hn.WithRetry(3), hn.WithBackoff(time.Millisecond, time.Millisecond, 0), hn.WithRetryBudget(0.5))
+
+	// The steps run in order: the budget of 10 tokens is drained by the retries of the failing items,
+	// and two successful requests add one token, allowing a single retry.
+	steps := []struct {
+		name     string
+		id       uint
+		requests int
+	}{
+		{"full budget", 1, 3},
+		{"draining", 2, 3},
+		{"draining", 3, 3},
+		{"draining", 4, 3},
+		{"last tokens", 5, 3},
+		{"drained", 6, 1},
+		{"still drained", 7, 1},
+		{"success", 100, 1},
+		{"success", 101, 1},
+		{"refilled", 8, 2},
+		{"drained again", 9, 1},
+	}
+
+	for _, step := range steps {
+		_, err := client.Items.Get(context.Background(), step.id)
+		if (err == nil) != (step.id >= 100) {
+			t.Fatalf("%s: Get(%d) error = %v", step.name, step.id, err)
+		}
+
+		if n := f.count("/item/" + itoa(step.id) + ".json"); n != step.requests {
+			t.Errorf("%s: got %d requests for item %d, want %d", step.name, n, step.id, step.requests)
+		}
+	}
+}
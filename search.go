@@ -0,0 +1,189 @@
+package hn
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const searchURL = "https://hn.algolia.com/api/v1/search"
+
+// SearchService provides methods to search Hacker News items using the Algolia HN Search API.
+type SearchService struct {
+	client *http.Client
+	opts   *options
+}
+
+// SearchOptions contains the optional parameters of a search query.
+type SearchOptions struct {
+	// Tags restricts the results to the items with all the given tags (e.g., "author_pg" or "story_8863").
+	Tags []string
+
+	// NumericFilters contains raw Algolia numeric filters (e.g., "points>=100").
+	NumericFilters []string
+
+	// MinPoints and MinComments restrict the results to the items with at least
+	// the given number of points and comments. A value of 0 means no restriction.
+	MinPoints   int
+	MinComments int
+
+	// After and Before restrict the results to the items created in the given time range.
+	// A zero time means no restriction.
+	After  time.Time
+	Before time.Time
+
+	// Page is the zero-based page number, and HitsPerPage is the number of results per page.
+	// A HitsPerPage of 0 means the default value of the API.
+	Page        int
+	HitsPerPage int
+}
+
+// values returns the query parameters of a search request for the given query and tag.
+func (o SearchOptions) values(query, tag string) url.Values {
+	tags := append([]string{tag}, o.Tags...)
+	filters := append([]string{}, o.NumericFilters...)
+
+	if o.MinPoints > 0 {
+		filters = append(filters, fmt.Sprintf("points>=%d", o.MinPoints))
+	}
+
+	if o.MinComments > 0 {
+		filters = append(filters, fmt.Sprintf("num_comments>=%d", o.MinComments))
+	}
+
+	if !o.After.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at_i>=%d", o.After.Unix()))
+	}
+
+	if !o.Before.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at_i<%d", o.Before.Unix()))
+	}
+
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("tags", strings.Join(tags, ","))
+
+	if len(filters) > 0 {
+		values.Set("numericFilters", strings.Join(filters, ","))
+	}
+
+	if o.Page > 0 {
+		values.Set("page", strconv.Itoa(o.Page))
+	}
+
+	if o.HitsPerPage > 0 {
+		values.Set("hitsPerPage", strconv.Itoa(o.HitsPerPage))
+	}
+
+	return values
+}
+
+// searchResult is a response of the Algolia HN Search API.
+type searchResult struct {
+	Hits []searchHit `json:"hits"`
+}
+
+// searchHit is a single item found by the Algolia HN Search API.
+type searchHit struct {
+	ObjectID    string `json:"objectID"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Author      string `json:"author"`
+	Points      int    `json:"points"`
+	StoryText   string `json:"story_text"`
+	CommentText string `json:"comment_text"`
+	NumComments int    `json:"num_comments"`
+	ParentID    uint   `json:"parent_id"`
+	CreatedAt   int64  `json:"created_at_i"`
+}
+
+func (h searchHit) base(itemType string) baseItem {
+	id, _ := strconv.ParseUint(h.ObjectID, 10, 0)
+
+	return baseItem{
+		ID:    uint(id),
+		By:    h.Author,
+		Score: h.Points,
+		Time:  Timestamp{time.Unix(h.CreatedAt, 0).UTC()},
+		Type:  itemType,
+	}
+}
+
+// Stories returns the stories matching the query.
+func (s *SearchService) Stories(ctx context.Context, query string, opts SearchOptions) ([]Story, error) {
+	hits, err := s.search(ctx, opts.values(query, StoryType))
+	if err != nil {
+		return nil, err
+	}
+
+	stories := make([]Story, 0, len(hits))
+
+	for _, hit := range hits {
+		story := Story{
+			baseItem:    hit.base(StoryType),
+			Descendants: hit.NumComments,
+			Text:        hit.StoryText,
+			Title:       hit.Title,
+			URL:         hit.URL,
+		}
+
+		if !s.opts.rawText {
+			story.rawText = story.Text
+			story.Text = html.UnescapeString(story.Text)
+			story.Title = html.UnescapeString(story.Title)
+		}
+
+		stories = append(stories, story)
+	}
+
+	return stories, nil
+}
+
+// Comments returns the comments matching the query.
+func (s *SearchService) Comments(ctx context.Context, query string, opts SearchOptions) ([]Comment, error) {
+	hits, err := s.search(ctx, opts.values(query, CommentType))
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, 0, len(hits))
+
+	for _, hit := range hits {
+		comment := Comment{
+			baseItem: hit.base(CommentType),
+			Parent:   hit.ParentID,
+			Text:     hit.CommentText,
+		}
+
+		if !s.opts.rawText {
+			comment.rawText = comment.Text
+			comment.Text = html.UnescapeString(comment.Text)
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// search sends a search request with the given query parameters and returns the found hits.
+func (s *SearchService) search(ctx context.Context, values url.Values) ([]searchHit, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, (searchURL + "?" + values.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create HTTP request: %w", err)
+	}
+
+	var result searchResult
+
+	err = do(s.client, s.opts, req, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Hits, nil
+}
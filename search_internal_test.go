@@ -0,0 +1,119 @@
+package hn
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchOptionsValues(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want url.Values
+	}{
+		{
+			"defaults",
+			SearchOptions{},
+			url.Values{"query": {"go"}, "tags": {"story"}},
+		},
+		{
+			"tags and page",
+			SearchOptions{Tags: []string{"author_pg"}, Page: 2, HitsPerPage: 50},
+			url.Values{"query": {"go"}, "tags": {"story,author_pg"}, "page": {"2"}, "hitsPerPage": {"50"}},
+		},
+		{
+			"numeric filters",
+			SearchOptions{
+				NumericFilters: []string{"points<1000"},
+				MinPoints:      100,
+				MinComments:    10,
+				After:          time.Unix(1000, 0),
+				Before:         time.Unix(2000, 0),
+			},
+			url.Values{
+				"query":          {"go"},
+				"tags":           {"story"},
+				"numericFilters": {"points<1000,points>=100,num_comments>=10,created_at_i>=1000,created_at_i<2000"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.values("go", StoryType); got.Encode() != tt.want.Encode() {
+				t.Errorf("values() = %s, want %s", got.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	const body = `{"hits":[
+		{"objectID":"1","title":"Go &amp; Rust","url":"https://go.dev","author":"pg","points":10,
+		 "story_text":"a &lt; b","num_comments":3,"created_at_i":1714564800},
+		{"objectID":"2","author":"dang","comment_text":"It&#x27;s <i>fine</i>","parent_id":1,"created_at_i":1714564800}
+	]}`
+
+	var requested *url.URL
+
+	client := NewClient(nil, WithTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requested = r.URL
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})))
+
+	stories, err := client.Search.Stories(context.Background(), "go", SearchOptions{MinPoints: 5})
+	if err != nil {
+		t.Fatalf("Stories() error = %v", err)
+	}
+
+	if got, want := requested.String(), searchURL+"?numericFilters=points%3E%3D5&query=go&tags=story"; got != want {
+		t.Errorf("request URL = %s, want %s", got, want)
+	}
+
+	type field struct {
+		name string
+		got  string
+		want string
+	}
+
+	// The hits are converted regardless of their type, which is set by the tag of the request.
+	tests := []field{
+		{"story title", stories[0].Title, "Go & Rust"},
+		{"story text", stories[0].Text, "a < b"},
+		{"story plain text", stories[0].Plain(), "a < b"},
+		{"story author", stories[0].By, "pg"},
+		{"story time", stories[0].Time.String(), "2024-05-01 12:00:00 +0000 UTC"},
+	}
+
+	comments, err := client.Search.Comments(context.Background(), "go", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Comments() error = %v", err)
+	}
+
+	tests = append(tests, []field{
+		{"comment text", comments[1].Text, "It's <i>fine</i>"},
+		{"comment plain text", comments[1].Plain(), "It's _fine_"},
+		{"comment type", comments[1].Type(), CommentType},
+		{"comment parent", strconv.FormatUint(uint64(comments[1].Parent), 10), "1"},
+	}...)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
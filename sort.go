@@ -14,9 +14,12 @@ type Sortable interface {
 	getScore() int
 	getTime() Timestamp
 	getType() string
+	getDescendants() int
+	getReplies() int
 }
 
 // Order represents the sorting order: ascending or descending.
+// Sort functions panic if the order is neither Ascending nor Descending.
 type Order int
 
 const (
@@ -29,66 +32,94 @@ func Sort[S Sortable](items []S, sort func(a, b S) int) {
 	slices.SortStableFunc(items, sort)
 }
 
-// SortID sorts the items by ID according to the specified order.
-func SortID[S Sortable](items []S, order Order) {
+// SortBy sorts the items by the key extracted with the key function according to the specified order.
+// It panics if the order is invalid.
+func SortBy[S Sortable, K cmp.Ordered](items []S, key func(S) K, order Order) {
+	Sort(items, compareBy(key, order))
+}
+
+// SortMulti sorts the items using multiple comparators in priority order:
+// the items are compared with the next comparator only if all previous ones report them as equal.
+func SortMulti[S Sortable](items []S, cmps ...func(a, b S) int) {
+	Sort(items, func(a, b S) int {
+		for _, compare := range cmps {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	})
+}
+
+// ByID returns a comparator of the items by ID for the specified order.
+func ByID[S Sortable](order Order) func(a, b S) int {
+	return compareBy(S.getID, order)
+}
+
+// ByScore returns a comparator of the items by score for the specified order.
+func ByScore[S Sortable](order Order) func(a, b S) int {
+	return compareBy(S.getScore, order)
+}
+
+// ByReplies returns a comparator of the items by the number of direct replies (kids) for the specified order.
+func ByReplies[S Sortable](order Order) func(a, b S) int {
+	return compareBy(S.getReplies, order)
+}
+
+// ByTime returns a comparator of the items by creation time for the specified order.
+func ByTime[S Sortable](order Order) func(a, b S) int {
+	return compareBy(func(s S) int64 {
+		return s.getTime().UnixNano()
+	}, order)
+}
+
+// compareBy returns a comparator of the keys extracted with the key function for the specified order.
+// It panics if the order is invalid.
+func compareBy[S any, K cmp.Ordered](key func(S) K, order Order) func(a, b S) int {
 	switch order {
 	case Ascending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(a.getID(), b.getID())
-		})
+		return func(a, b S) int {
+			return cmp.Compare(key(a), key(b))
+		}
 	case Descending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(b.getID(), a.getID())
-		})
+		return func(a, b S) int {
+			return cmp.Compare(key(b), key(a))
+		}
 	default:
-		fmt.Printf("Invalid sort order: %v", order)
+		panic(fmt.Sprintf("hn: invalid sort order: %d", order))
 	}
 }
 
+// SortID sorts the items by ID according to the specified order.
+func SortID[S Sortable](items []S, order Order) {
+	SortBy(items, S.getID, order)
+}
+
 // SortScore sorts the items by score according to the specified order.
 func SortScore[S Sortable](items []S, order Order) {
-	switch order {
-	case Ascending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(a.getScore(), b.getScore())
-		})
-	case Descending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(b.getScore(), a.getScore())
-		})
-	default:
-		fmt.Printf("Invalid sort order: %v", order)
-	}
+	SortBy(items, S.getScore, order)
 }
 
 // SortTime sorts the items by creation time according to the specified order.
 func SortTime[S Sortable](items []S, order Order) {
-	switch order {
-	case Ascending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(a.getTime().UnixNano(), b.getTime().UnixNano())
-		})
-	case Descending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(b.getTime().UnixNano(), a.getTime().UnixNano())
-		})
-	default:
-		fmt.Printf("Invalid sort order: %v", order)
-	}
+	Sort(items, ByTime[S](order))
 }
 
 // SortType sorts the items by type according to the specified order.
 func SortType[S Sortable](items []S, order Order) {
-	switch order {
-	case Ascending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(a.getType(), b.getType())
-		})
-	case Descending:
-		Sort(items, func(a, b S) int {
-			return cmp.Compare(b.getType(), a.getType())
-		})
-	default:
-		fmt.Printf("Invalid sort order: %v", order)
-	}
+	SortBy(items, S.getType, order)
+}
+
+// SortDescendants sorts the items by the number of descendants (comments) according to the specified order.
+// Items without descendants (e.g., Comment or PollOption) are treated as having 0 descendants.
+func SortDescendants[S Sortable](items []S, order Order) {
+	SortBy(items, S.getDescendants, order)
+}
+
+// SortReplies sorts the items by the number of direct replies (kids) according to the specified order,
+// e.g., to show the most discussed comments of a thread first.
+// Items without kids (e.g., Job or PollOption) are treated as having 0 replies.
+func SortReplies[S Sortable](items []S, order Order) {
+	SortBy(items, S.getReplies, order)
 }
@@ -0,0 +1,207 @@
+package hn_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+// storiesOf returns the stories with the given IDs, where the story i has i*10 comments
+// unless it's listed in descendants.
+func storiesOf(descendants map[uint]int, ids ...uint) []hn.Story {
+	stories := make([]hn.Story, len(ids))
+	for i, id := range ids {
+		stories[i] = hn.ToStory(newStory(id, 0))
+		stories[i].Descendants = int(id) * 10
+
+		if n, ok := descendants[id]; ok {
+			stories[i].Descendants = n
+		}
+	}
+
+	return stories
+}
+
+func TestSortDescendants(t *testing.T) {
+	tests := []struct {
+		name        string
+		descendants map[uint]int
+		order       hn.Order
+		want        []uint
+	}{
+		{"ascending", nil, hn.Ascending, []uint{1, 2, 3}},
+		{"descending", nil, hn.Descending, []uint{3, 2, 1}},
+		{"stable for equal counts", map[uint]int{1: 5, 3: 5}, hn.Descending, []uint{2, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stories := storiesOf(tt.descendants, 3, 1, 2)
+			hn.SortDescendants(stories, tt.order)
+
+			if got := hn.IDsOf(stories); !slices.Equal(got, tt.want) {
+				t.Errorf("SortDescendants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The comments have no descendants, so their order is kept.
+	comments := []hn.Comment{hn.ToComment(newComment(2, 1)), hn.ToComment(newComment(1, 0))}
+	hn.SortDescendants(comments, hn.Descending)
+
+	if got, want := hn.IDsOf(comments), []uint{2, 1}; !slices.Equal(got, want) {
+		t.Errorf("SortDescendants(comments) = %v, want %v", got, want)
+	}
+}
+
+func TestSortReplies(t *testing.T) {
+	comments := func() []hn.Comment {
+		return hn.ToList[hn.Comment]([]hn.Item{
+			newComment(1, 0, 10, 11),
+			newComment(2, 0),
+			newComment(3, 0, 12, 13, 14),
+			newComment(4, 0, 15),
+			newComment(5, 0, 16, 17),
+		})
+	}
+
+	tests := []struct {
+		name string
+		sort func([]hn.Comment)
+		want []uint
+	}{
+		{"ascending", func(c []hn.Comment) { hn.SortReplies(c, hn.Ascending) }, []uint{2, 4, 1, 5, 3}},
+		{"descending", func(c []hn.Comment) { hn.SortReplies(c, hn.Descending) }, []uint{3, 1, 5, 4, 2}},
+		{"comparator", func(c []hn.Comment) { hn.Sort(c, hn.ByReplies[hn.Comment](hn.Descending)) }, []uint{3, 1, 5, 4, 2}},
+		{
+			"comparator with ties broken by ID",
+			func(c []hn.Comment) {
+				hn.SortMulti(c, hn.ByReplies[hn.Comment](hn.Descending), func(a, b hn.Comment) int { return int(b.ID) - int(a.ID) })
+			},
+			[]uint{3, 5, 1, 4, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := comments()
+			tt.sort(c)
+
+			if got := hn.IDsOf(c); !slices.Equal(got, tt.want) {
+				t.Errorf("sorted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The jobs have no kids, so their order is kept.
+	jobs := hn.ToList[hn.Job]([]hn.Item{newItem(2, hn.JobType), newItem(1, hn.JobType)})
+	hn.SortReplies(jobs, hn.Descending)
+
+	if got, want := hn.IDsOf(jobs), []uint{2, 1}; !slices.Equal(got, want) {
+		t.Errorf("SortReplies(jobs) = %v, want %v", got, want)
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	items := func() []hn.Item {
+		first, second, third := newStory(2, 30), newComment(3, 2), newStory(1, 10)
+		first.Title, third.Title = "b", "a"
+		first.Time = hn.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		second.Time = hn.Timestamp{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		third.Time = hn.Timestamp{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		return []hn.Item{first, second, third}
+	}
+
+	tests := []struct {
+		name string
+		sort func(items []hn.Item)
+		want []uint
+	}{
+		{"by title ascending", func(items []hn.Item) {
+			hn.SortBy(items, func(item hn.Item) string { return item.Title }, hn.Ascending)
+		}, []uint{3, 1, 2}},
+		{"by title descending", func(items []hn.Item) {
+			hn.SortBy(items, func(item hn.Item) string { return item.Title }, hn.Descending)
+		}, []uint{2, 1, 3}},
+		{"by id", func(items []hn.Item) { hn.SortID(items, hn.Ascending) }, []uint{1, 2, 3}},
+		{"by score", func(items []hn.Item) { hn.SortScore(items, hn.Descending) }, []uint{2, 1, 3}},
+		{"by time", func(items []hn.Item) { hn.SortTime(items, hn.Ascending) }, []uint{3, 2, 1}},
+		{"by type", func(items []hn.Item) { hn.SortType(items, hn.Descending) }, []uint{2, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := items()
+			tt.sort(list)
+
+			if got := hn.IDsOf(list); !slices.Equal(got, tt.want) {
+				t.Errorf("sorted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortInvalidOrder(t *testing.T) {
+	const invalid = hn.Order(2)
+
+	tests := []struct {
+		name string
+		sort func(items []hn.Item)
+	}{
+		{"SortID", func(items []hn.Item) { hn.SortID(items, invalid) }},
+		{"SortScore", func(items []hn.Item) { hn.SortScore(items, invalid) }},
+		{"SortTime", func(items []hn.Item) { hn.SortTime(items, invalid) }},
+		{"SortBy", func(items []hn.Item) {
+			hn.SortBy(items, func(item hn.Item) string { return item.Title }, invalid)
+		}},
+		// The comparators panic when they're created, even if there is nothing to sort.
+		{"ByScore", func([]hn.Item) { hn.ByScore[hn.Item](invalid) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s didn't panic for an invalid order", tt.name)
+				}
+			}()
+
+			tt.sort([]hn.Item{newStory(1, 1), newStory(2, 2)})
+		})
+	}
+}
+
+func TestSortMulti(t *testing.T) {
+	items := func() []hn.Item {
+		list := []hn.Item{newStory(1, 10), newStory(2, 20), newStory(3, 10), newStory(4, 20)}
+		list[0].Time = hn.Timestamp{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+		list[2].Time = hn.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		return list
+	}
+
+	tests := []struct {
+		name string
+		cmps []func(a, b hn.Item) int
+		want []uint
+	}{
+		{"no comparators", nil, []uint{1, 2, 3, 4}},
+		{"score, then ID", []func(a, b hn.Item) int{hn.ByScore[hn.Item](hn.Descending), hn.ByID[hn.Item](hn.Descending)}, []uint{4, 2, 3, 1}},
+		{"score, then time", []func(a, b hn.Item) int{hn.ByScore[hn.Item](hn.Ascending), hn.ByTime[hn.Item](hn.Ascending)}, []uint{3, 1, 2, 4}},
+		{"ID only", []func(a, b hn.Item) int{hn.ByID[hn.Item](hn.Descending)}, []uint{4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := items()
+			hn.SortMulti(list, tt.cmps...)
+
+			if got := hn.IDsOf(list); !slices.Equal(got, tt.want) {
+				t.Errorf("SortMulti() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
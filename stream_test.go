@@ -0,0 +1,121 @@
+package hn_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+// slowItems returns a fixture serving the items 1 to n, where only the items up to fast are answered
+// right away, and the others wait until their requests are canceled, counting the cancellations in aborted.
+// The requests other than the item requests (e.g., for a user) are answered right away.
+func slowItems(n, fast uint, aborted *atomic.Int32) *fixture {
+	f := &fixture{items: make(map[uint]hn.Item)}
+
+	for id := uint(1); id <= n; id++ {
+		f.items[id] = newStory(id, int(id))
+	}
+
+	f.handler = func(w http.ResponseWriter, r *http.Request) bool {
+		if !strings.HasPrefix(r.URL.Path, "/item/") {
+			return false
+		}
+
+		for id := uint(1); id <= fast; id++ {
+			if r.URL.Path == "/item/"+itoa(id)+".json" {
+				return false
+			}
+		}
+
+		<-r.Context().Done()
+		aborted.Add(1)
+
+		return true
+	}
+
+	return f
+}
+
+func TestStream(t *testing.T) {
+	f := &fixture{items: itemsOf(newStory(1, 10), newStory(2, 20), newStory(3, 30))}
+	client := newTestClient(t, f)
+
+	var ids []uint
+
+	for item, err := range client.Items.Stream(context.Background(), []uint{1, 2, 3}, hn.MinScore(20)) {
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+
+		ids = append(ids, item.ID)
+	}
+
+	slices.Sort(ids)
+
+	if want := []uint{2, 3}; !slices.Equal(ids, want) {
+		t.Errorf("Stream() = %v, want %v", ids, want)
+	}
+}
+
+func TestStreamBreakCancelsRequests(t *testing.T) {
+	var aborted atomic.Int32
+
+	f := slowItems(100, 3, &aborted)
+	client := newTestClient(t, f, hn.WithMaxWorkers(10))
+
+	var n int
+
+	for _, err := range client.Items.Stream(context.Background(), idRange(1, 100), nil) {
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+
+		if n++; n == 3 {
+			break
+		}
+	}
+
+	// The workers of the stream are stopped by the break, and the shared requests they started are canceled.
+	waitFor(t, func() bool { return leaked() == "" })
+
+	// Only the requests of the workers were sent, and all of them were canceled. A canceled request
+	// can reach the server after the workers have stopped, so the total is read again while waiting.
+	if sent := f.total(); sent > 3+10 {
+		t.Errorf("got %d requests, want at most %d", sent, 3+10)
+	}
+
+	waitFor(t, func() bool { return int(aborted.Load()) == f.total()-3 })
+}
+
+func TestUserStreamBreak(t *testing.T) {
+	var aborted atomic.Int32
+
+	f := slowItems(100, 3, &aborted)
+	f.users = map[string]hn.User{"pg": {ID: "pg", Submitted: idRange(1, 100)}}
+
+	client := newTestClient(t, f, hn.WithMaxWorkers(10))
+
+	var ids []uint
+
+	for item, err := range client.Users.Stream(context.Background(), "pg", nil) {
+		if err != nil {
+			t.Fatalf("Stream() error = %v", err)
+		}
+
+		if ids = append(ids, item.ID); len(ids) == 3 {
+			break
+		}
+	}
+
+	// The submissions are yielded in their order.
+	if want := []uint{1, 2, 3}; !slices.Equal(ids, want) {
+		t.Errorf("Stream() = %v, want %v", ids, want)
+	}
+
+	waitFor(t, func() bool { return leaked() == "" })
+}
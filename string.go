@@ -0,0 +1,49 @@
+package hn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// snippetLength is the maximum number of characters of the text snippets in the String methods.
+const snippetLength = 50
+
+func (s Story) String() string {
+	return fmt.Sprintf("Story#%d %q by %s (%d pts, %d comments)", s.ID, s.Title, s.By, s.Score, s.Descendants)
+}
+
+func (c Comment) String() string {
+	return fmt.Sprintf("Comment#%d by %s: %q", c.ID, c.By, snippet(c.markup(c.Text)))
+}
+
+func (a Ask) String() string {
+	return fmt.Sprintf("Ask#%d %q by %s (%d pts, %d comments)", a.ID, a.Title, a.By, a.Score, a.Descendants)
+}
+
+func (j Job) String() string {
+	return fmt.Sprintf("Job#%d %q by %s", j.ID, j.Title, j.By)
+}
+
+func (p Poll) String() string {
+	return fmt.Sprintf("Poll#%d %q by %s (%d pts, %d comments, %d options)", p.ID, p.Title, p.By, p.Score, p.Descendants, len(p.Parts))
+}
+
+func (o PollOption) String() string {
+	return fmt.Sprintf("PollOption#%d %q (%d pts)", o.ID, snippet(o.markup(o.Text)), o.Score)
+}
+
+func (u User) String() string {
+	return fmt.Sprintf("User %s (%d karma, %d submissions)", u.ID, u.Karma, len(u.Submitted))
+}
+
+// snippet returns the beginning of the text converted to a single line of plain text.
+// The text must be HTML, as PlainText expects (see baseItem.markup).
+func snippet(text string) string {
+	text = strings.Join(strings.Fields(PlainText(text)), " ")
+
+	if runes := []rune(text); len(runes) > snippetLength {
+		return string(runes[:snippetLength]) + "..."
+	}
+
+	return text
+}
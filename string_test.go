@@ -0,0 +1,62 @@
+package hn_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestString(t *testing.T) {
+	story := newStory(1, 10, 2)
+	story.By, story.Descendants = "pg", 1
+
+	comment := newComment(2, 1)
+	comment.By = "dang"
+	comment.Text = "use &lt;div&gt;, a &amp;lt; b<p>and <i>more</i>"
+
+	long := newComment(3, 1)
+	long.Text = strings.Repeat("abc ", 20)
+
+	var user hn.User
+	user.ID, user.Karma, user.Submitted = "pg", 100, []uint{1, 2}
+
+	tests := []struct {
+		name string
+		got  func(item hn.Item) string
+		item hn.Item
+		want string
+	}{
+		{"story", func(item hn.Item) string { return hn.ToStory(item).String() }, story, `Story#1 "Story 1" by pg (10 pts, 1 comments)`},
+		{"comment", func(item hn.Item) string { return hn.ToComment(item).String() }, comment, `Comment#2 by dang: "use <div>, a &lt; b and _more_"`},
+		{
+			"long comment",
+			func(item hn.Item) string { return hn.ToComment(item).String() },
+			long,
+			`Comment#3 by : "` + strings.Repeat("abc ", 12) + `ab..."`,
+		},
+		{"user", func(hn.Item) string { return user.String() }, hn.Item{}, "User pg (100 karma, 2 submissions)"},
+	}
+
+	client := newTestClient(t, &fixture{items: itemsOf(story, comment, long)})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+
+			if item.ID != 0 {
+				var err error
+
+				item, err = client.Items.Get(context.Background(), item.ID)
+				if err != nil {
+					t.Fatalf("Get(%d) error = %v", tt.item.ID, err)
+				}
+			}
+
+			if got := tt.got(item); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package hn
+
+import (
+	"html"
+	"strings"
+)
+
+// PlainText converts the HTML of Hacker News texts to readable plain text. The text must be the HTML
+// as it's stored on Hacker News (e.g., Comment.Text of a client created with WithRawText),
+// since the text unescaped by ItemService no longer tells the tags apart from the "<" typed by the users.
+// Use Comment.Plain or Story.Plain to render the texts of the items regardless of the client options.
+//
+// Paragraphs are separated by blank lines, links are written as "text (url)",
+// italic text is written as _text_, and preformatted blocks are indented by four spaces.
+// HTML entities are decoded, and other tags are removed.
+func PlainText(s string) string {
+	var b strings.Builder
+
+	for s != "" {
+		start := strings.IndexByte(s, '<')
+		if start < 0 {
+			b.WriteString(html.UnescapeString(s))
+			break
+		}
+
+		b.WriteString(html.UnescapeString(s[:start]))
+		s = s[start:]
+
+		end := strings.IndexByte(s, '>')
+		if end < 0 || !isTag(s[1:end]) {
+			// A "<" that doesn't start a tag is kept as is.
+			b.WriteByte('<')
+			s = s[1:]
+			continue
+		}
+
+		name, attrs, _ := strings.Cut(s[1:end], " ")
+		s = s[end+1:]
+
+		switch strings.ToLower(name) {
+		case "p":
+			paragraph(&b)
+		case "i", "/i":
+			b.WriteByte('_')
+		case "a":
+			var text string
+
+			text, s, _ = strings.Cut(s, "</a>")
+			b.WriteString(link(PlainText(text), html.UnescapeString(attr(attrs, "href"))))
+		case "pre":
+			var code string
+
+			code, s, _ = strings.Cut(s, "</pre>")
+			code = strings.TrimPrefix(code, "<code>")
+			code = strings.TrimSuffix(code, "</code>")
+			code = strings.TrimRight(html.UnescapeString(code), "\n")
+
+			paragraph(&b)
+
+			for i, line := range strings.Split(code, "\n") {
+				if i > 0 {
+					b.WriteByte('\n')
+				}
+
+				if line != "" {
+					b.WriteString("    " + line)
+				}
+			}
+
+			b.WriteByte('\n')
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// Plain returns the text of the comment converted to plain text with PlainText.
+// The original HTML of the text is converted, even if the text was unescaped.
+func (c Comment) Plain() string {
+	return PlainText(c.markup(c.Text))
+}
+
+// Plain returns the text of the story converted to plain text with PlainText.
+// The original HTML of the text is converted, even if the text was unescaped.
+func (s Story) Plain() string {
+	return PlainText(s.markup(s.Text))
+}
+
+// paragraph ends the current paragraph with a blank line, unless the text is empty or already ends with one.
+func paragraph(b *strings.Builder) {
+	text := b.String()
+
+	switch {
+	case text == "", strings.HasSuffix(text, "\n\n"):
+	case strings.HasSuffix(text, "\n"):
+		b.WriteByte('\n')
+	default:
+		b.WriteString("\n\n")
+	}
+}
+
+// link returns the text of a link followed by its URL. Hacker News shortens long URLs
+// in the text of links (e.g., "https://example.com/very/lo..."), so if the text is
+// the same as the URL, or the shortened version of it, only the URL is returned.
+func link(text, href string) string {
+	if href == "" {
+		return text
+	}
+
+	if text == "" || strings.HasPrefix(href, strings.TrimSuffix(text, "...")) {
+		return href
+	}
+
+	return text + " (" + href + ")"
+}
+
+// isTag reports whether the text between "<" and ">" looks like an HTML tag.
+func isTag(tag string) bool {
+	tag = strings.TrimPrefix(tag, "/")
+
+	return tag != "" && (tag[0] >= 'a' && tag[0] <= 'z' || tag[0] >= 'A' && tag[0] <= 'Z')
+}
+
+// attr returns the value of the attribute with the given name from the attributes of an HTML tag.
+func attr(attrs, name string) string {
+	_, value, ok := strings.Cut(attrs, name+`="`)
+	if !ok {
+		return ""
+	}
+
+	value, _, _ = strings.Cut(value, `"`)
+
+	return value
+}
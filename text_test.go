@@ -0,0 +1,102 @@
+package hn_test
+
+import (
+	"context"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"empty", "", ""},
+		{"entities", "It&#x27;s &quot;fine&quot; &amp; done", `It's "fine" & done`},
+		{"escaped tags", "use &lt;div&gt; here", "use <div> here"},
+		{"literal less than", "a < b and c<d", "a < b and c<d"},
+		{"literal ampersand", "this & that", "this & that"},
+		{"paragraphs", "first<p>second<p>third", "first\n\nsecond\n\nthird"},
+		{"italic", "<i>very</i> nice", "_very_ nice"},
+		{
+			"link",
+			`see <a href="https:&#x2F;&#x2F;example.com&#x2F;a?b=1&amp;c=2" rel="nofollow">the docs</a>`,
+			"see the docs (https://example.com/a?b=1&c=2)",
+		},
+		{
+			"link with url as text",
+			`<a href="https:&#x2F;&#x2F;example.com&#x2F;a" rel="nofollow">https:&#x2F;&#x2F;example.com&#x2F;a</a>`,
+			"https://example.com/a",
+		},
+		{
+			"preformatted",
+			"code:<p><pre><code>  if a &lt; b {\n  }\n</code></pre>",
+			"code:\n\n      if a < b {\n      }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hn.PlainText(tt.text); got != tt.want {
+				t.Errorf("PlainText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlain(t *testing.T) {
+	const raw = "use &lt;div&gt; and &lt;i&gt;x&lt;&#x2F;i&gt;, a &amp;lt; b<p><i>done</i>"
+
+	const want = "use <div> and <i>x</i>, a &lt; b\n\n_done_"
+
+	story := newStory(1, 1, 2)
+	story.Text = raw
+
+	comment := newComment(2, 1)
+	comment.Text = raw
+
+	f := &fixture{items: itemsOf(story, comment)}
+
+	tests := []struct {
+		name string
+		opts []hn.Option
+	}{
+		{"unescaped", nil},
+		{"raw text", []hn.Option{hn.WithRawText()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, f, tt.opts...)
+
+			item, err := client.Items.Get(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Get(1) error = %v", err)
+			}
+
+			if got := hn.ToStory(item).Plain(); got != want {
+				t.Errorf("Story.Plain() = %q, want %q", got, want)
+			}
+
+			item, err = client.Items.Get(context.Background(), 2)
+			if err != nil {
+				t.Fatalf("Get(2) error = %v", err)
+			}
+
+			if got := hn.ToComment(item).Plain(); got != want {
+				t.Errorf("Comment.Plain() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPlainUnescaped(t *testing.T) {
+	var item hn.Item
+	item.Text = "a &lt; b<p>c &amp; d"
+
+	if got, want := hn.ToComment(item.Unescaped().Unescaped()).Plain(), "a < b\n\nc & d"; got != want {
+		t.Errorf("Plain() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,463 @@
+package hn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// ThreadNode represents an item of a discussion thread with its replies.
+type ThreadNode struct {
+	Item     Item
+	Children []*ThreadNode
+}
+
+// Thread returns a discussion tree for the item with the specified ID,
+// fetching the replies recursively up to maxDepth levels below the root.
+// A maxDepth of 0 means there is no limit to the depth of the tree.
+//
+// The replies of each level are fetched concurrently, bounded by the worker limit.
+// Replies that are not found are skipped, along with the deleted and dead replies and their subtrees
+// if WithSkipDeadDeleted is set. An item is never included in the tree more than once,
+// so an item listing itself (or one of its ancestors) as a kid doesn't cause an infinite loop.
+// If ctx is canceled between the levels, Thread returns ctx.Err() without fetching the next level.
+func (s *ItemService) Thread(ctx context.Context, rootID uint, maxDepth int) (*ThreadNode, error) {
+	root, err := s.Get(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tree  = &ThreadNode{Item: root}
+		level = []*ThreadNode{tree}
+		seen  = map[uint]bool{rootID: true}
+	)
+
+	for depth := 1; len(level) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		// The next level isn't fetched (or taken from the item cache) once ctx is canceled.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var (
+			parents []*ThreadNode
+			ids     []uint
+		)
+
+		for _, node := range level {
+			for _, kid := range node.Item.Kids {
+				if seen[kid] {
+					continue
+				}
+
+				seen[kid] = true
+				parents = append(parents, node)
+				ids = append(ids, kid)
+			}
+		}
+
+		kids, err := s.getEach(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		level = make([]*ThreadNode, 0, len(kids))
+
+		for i, kid := range kids {
+			if s.skipReply(kid) {
+				continue
+			}
+
+			node := &ThreadNode{Item: *kid}
+			parents[i].Children = append(parents[i].Children, node)
+			level = append(level, node)
+		}
+	}
+
+	return tree, nil
+}
+
+// skipReply reports whether a reply is left out of a thread along with its own replies: the replies
+// that are not found, and the deleted and dead replies if WithSkipDeadDeleted is set.
+func (s *ItemService) skipReply(kid *Item) bool {
+	return kid == nil || s.opts.skipDeadDeleted && !FilterAlive(*kid)
+}
+
+// GetWithKids returns an item with the specified ID along with its direct kids (e.g., the top-level comments of a story),
+// filtered if necessary. The kids are fetched concurrently and returned in their original order,
+// and the kids that are missing or deleted are dropped.
+func (s *ItemService) GetWithKids(ctx context.Context, id uint, filter func(Item) bool) (Item, []Item, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return Item{}, nil, err
+	}
+
+	kids, err := s.getEach(ctx, item.Kids)
+	if err != nil {
+		return Item{}, nil, err
+	}
+
+	list := make([]Item, 0, len(kids))
+
+	for _, kid := range kids {
+		if kid != nil && !kid.Deleted && s.keep(*kid, filter) {
+			list = append(list, *kid)
+		}
+	}
+
+	return item, list, nil
+}
+
+// Ancestors returns the chain of the ancestors of the item with the specified ID,
+// ordered from the top-level item (e.g., a story) to the direct parent of the item.
+// The item itself isn't included, so the chain is empty for a top-level item.
+//
+// It returns an error wrapping ErrNotFound if any item of the chain is missing,
+// and ErrCycle if the parent links form a cycle.
+func (s *ItemService) Ancestors(ctx context.Context, id uint) ([]Item, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		chain []Item
+		seen  = map[uint]bool{id: true}
+	)
+
+	for item.Parent != 0 {
+		if seen[item.Parent] {
+			return nil, ErrCycle
+		}
+
+		seen[item.Parent] = true
+
+		parent, err := s.Get(ctx, item.Parent)
+		if err != nil {
+			return nil, fmt.Errorf("parent %d of item %d: %w", item.Parent, item.ID, err)
+		}
+
+		chain = append(chain, parent)
+		item = parent
+	}
+
+	slices.Reverse(chain)
+
+	return chain, nil
+}
+
+// Root returns the top-level item (e.g., a story, an ask or a poll) that the item with the specified ID
+// belongs to, following the parent links until an item that is not a comment is reached.
+// If the item is not a comment, the item itself is returned.
+//
+// It returns an error wrapping ErrNotFound if any item of the chain is missing,
+// and ErrCycle if the parent links form a cycle.
+func (s *ItemService) Root(ctx context.Context, id uint) (Item, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return Item{}, err
+	}
+
+	seen := map[uint]bool{id: true}
+
+	for item.Type == CommentType {
+		if seen[item.Parent] {
+			return Item{}, ErrCycle
+		}
+
+		seen[item.Parent] = true
+
+		parent, err := s.Get(ctx, item.Parent)
+		if err != nil {
+			return Item{}, fmt.Errorf("parent %d of item %d: %w", item.Parent, item.ID, err)
+		}
+
+		item = parent
+	}
+
+	return item, nil
+}
+
+// AnnotateRoots returns the titles of the top-level items (e.g., stories) of the comments, keyed by the comment IDs.
+// The parent chains of the comments are resolved concurrently, fetching each item only once, even if it's shared
+// by the chains of several comments. If the top-level item of a comment is not found, its title is empty.
+func (s *ItemService) AnnotateRoots(ctx context.Context, comments []Comment) (map[uint]string, error) {
+	roots, err := s.roots(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[uint]string, len(comments))
+	for _, comment := range comments {
+		titles[comment.ID] = roots[comment.Parent].Title
+	}
+
+	return titles, nil
+}
+
+// roots returns the top-level items of the comments keyed by the IDs of their parents.
+// The parent chains are walked level by level: the items of each level are fetched concurrently,
+// and an item shared by several chains (e.g., a story with many comments) is fetched only once.
+// The roots that are not found are not included, and an *ItemError wrapping ErrCycle is returned
+// if the parent links of a comment form a cycle.
+func (s *ItemService) roots(ctx context.Context, comments []Comment) (map[uint]Item, error) {
+	var (
+		parents = make(map[uint]uint) // fetched comments and their parents
+		top     = make(map[uint]Item) // fetched top-level items
+		missing = make(map[uint]bool) // items that are not found
+	)
+
+	known := func(id uint) bool {
+		_, comment := parents[id]
+		_, root := top[id]
+
+		return comment || root || missing[id]
+	}
+
+	level := make([]uint, 0, len(comments))
+	for _, comment := range comments {
+		level = append(level, comment.Parent)
+	}
+
+	slices.Sort(level)
+	level = slices.Compact(level)
+
+	for len(level) > 0 {
+		items, err := s.getEach(ctx, level)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, item := range items {
+			switch {
+			case item == nil:
+				missing[level[i]] = true
+			case item.Type == CommentType:
+				parents[item.ID] = item.Parent
+			default:
+				top[item.ID] = *item
+			}
+		}
+
+		var next []uint
+
+		for _, item := range items {
+			if item != nil && item.Type == CommentType && !known(item.Parent) {
+				next = append(next, item.Parent)
+			}
+		}
+
+		slices.Sort(next)
+		level = slices.Compact(next)
+	}
+
+	roots := make(map[uint]Item, len(top))
+
+	for _, comment := range comments {
+		if _, ok := roots[comment.Parent]; ok {
+			continue
+		}
+
+		seen := map[uint]bool{comment.ID: true}
+
+		for id := comment.Parent; !missing[id]; id = parents[id] {
+			if root, ok := top[id]; ok {
+				roots[comment.Parent] = root
+				break
+			}
+
+			if seen[id] {
+				return nil, &ItemError{ID: comment.ID, Err: ErrCycle}
+			}
+
+			seen[id] = true
+		}
+	}
+
+	return roots, nil
+}
+
+// getEach fetches the items with specific IDs concurrently, bounded by the worker limit.
+// The returned slice is aligned with ids, and the items that are not found are left nil.
+func (s *ItemService) getEach(ctx context.Context, ids []uint) ([]*Item, error) {
+	items := make([]*Item, len(ids))
+
+	err := s.forEach(ctx, len(ids), func(ctx context.Context, i int) error {
+		item, err := s.Get(ctx, ids[i])
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return &ItemError{ID: ids[i], Err: err}
+		}
+
+		items[i] = &item
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Walk traverses the thread tree in depth-first order, calling fn for each node.
+// The root is visited at depth 0, and the depth is incremented for each level.
+// If fn returns false, the children of the current node are not visited.
+func Walk(node *ThreadNode, fn func(depth int, item Item) bool) {
+	walk(node, 0, fn)
+}
+
+func walk(node *ThreadNode, depth int, fn func(depth int, item Item) bool) {
+	if node == nil || !fn(depth, node.Item) {
+		return
+	}
+
+	for _, child := range node.Children {
+		walk(child, depth+1, fn)
+	}
+}
+
+// Stats contains the aggregate statistics of a thread.
+type Stats struct {
+	Comments     int         // number of comments in the thread
+	Participants []string    // unique authors of the comments, in the order of their first comment
+	TopCommenter string      // author of the most comments (the first one in case of a tie)
+	MaxDepth     int         // depth of the deepest comment, where the root is at depth 0
+	Deepest      *ThreadNode // first comment at the maximum depth
+}
+
+// ThreadStats returns the statistics of the comments in the thread tree.
+// Only comments are counted, so the root story (or any other non-comment item) is not included.
+func ThreadStats(node *ThreadNode) Stats {
+	var (
+		stats  Stats
+		counts = make(map[string]int)
+	)
+
+	var visit func(node *ThreadNode, depth int)
+
+	visit = func(node *ThreadNode, depth int) {
+		if node.Item.Type == CommentType {
+			stats.Comments++
+
+			if by := node.Item.By; by != "" {
+				if counts[by] == 0 {
+					stats.Participants = append(stats.Participants, by)
+				}
+
+				counts[by]++
+			}
+
+			if stats.Deepest == nil || depth > stats.MaxDepth {
+				stats.MaxDepth, stats.Deepest = depth, node
+			}
+		}
+
+		for _, child := range node.Children {
+			visit(child, depth+1)
+		}
+	}
+
+	if node != nil {
+		visit(node, 0)
+	}
+
+	for _, by := range stats.Participants {
+		if counts[by] > counts[stats.TopCommenter] {
+			stats.TopCommenter = by
+		}
+	}
+
+	return stats
+}
+
+// CountComments returns the number of comments in the thread of the item with the specified ID,
+// fetching the tree with Thread up to maxDepth levels below the root (0 means no limit).
+// Unlike the Descendants field reported by the API, the deleted comments are not counted.
+func (s *ItemService) CountComments(ctx context.Context, rootID uint, maxDepth int) (int, error) {
+	tree, err := s.Thread(ctx, rootID, maxDepth)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+
+	Walk(tree, func(_ int, item Item) bool {
+		if item.Type == CommentType && !item.Deleted {
+			count++
+		}
+
+		return true
+	})
+
+	return count, nil
+}
+
+// CommentAt is a comment of a thread along with its depth, where the direct replies to the root are at depth 1.
+type CommentAt struct {
+	Depth   int
+	Comment Comment
+}
+
+// Comments returns an iterator over the comments of the thread of the item with the specified ID
+// in depth-first (reading) order, up to maxDepth levels below the root (0 means no limit).
+// The root itself is not included.
+//
+// The comments are fetched lazily: the replies to a comment are fetched concurrently when the iteration
+// reaches the comment, so the top of a deep thread can be rendered before the rest of it is loaded.
+// Replies are skipped like in Thread, and the iteration stops after the first error.
+func (s *ItemService) Comments(ctx context.Context, rootID uint, maxDepth int) iter.Seq2[CommentAt, error] {
+	return func(yield func(CommentAt, error) bool) {
+		root, err := s.Get(ctx, rootID)
+		if err != nil {
+			yield(CommentAt{}, err)
+			return
+		}
+
+		seen := map[uint]bool{rootID: true}
+
+		var visit func(item Item, depth int) bool
+
+		// visit yields the replies to the item at the given depth, reporting whether the iteration should continue.
+		visit = func(item Item, depth int) bool {
+			if maxDepth > 0 && depth > maxDepth {
+				return true
+			}
+
+			var ids []uint
+
+			for _, kid := range item.Kids {
+				if !seen[kid] {
+					seen[kid] = true
+					ids = append(ids, kid)
+				}
+			}
+
+			kids, err := s.getEach(ctx, ids)
+			if err != nil {
+				yield(CommentAt{}, err)
+				return false
+			}
+
+			for _, kid := range kids {
+				if s.skipReply(kid) || kid.Type != CommentType {
+					continue
+				}
+
+				if !yield(CommentAt{Depth: depth, Comment: ToComment(*kid)}, nil) || !visit(*kid, depth+1) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		visit(root, 1)
+	}
+}
@@ -0,0 +1,666 @@
+package hn_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+// threads returns a fixture with two stories: story 1 with a deep subthread (2 -> 3 -> 4 and 3 -> 5)
+// and a comment 6, and story 10 with a comment 11. The comment 21 is under the missing item 20.
+func threads() *fixture {
+	return &fixture{
+		items: itemsOf(
+			newStory(1, 10, 2, 6),
+			newComment(2, 1, 3),
+			newComment(3, 2, 4, 5),
+			newComment(4, 3),
+			newComment(5, 3),
+			newComment(6, 1),
+			newStory(10, 20, 11),
+			newComment(11, 10),
+			newComment(21, 20),
+		),
+	}
+}
+
+func commentsOf(f *fixture, ids ...uint) []hn.Comment {
+	comments := make([]hn.Comment, len(ids))
+	for i, id := range ids {
+		comments[i] = hn.ToComment(f.items[id])
+	}
+
+	return comments
+}
+
+func TestAnnotateRoots(t *testing.T) {
+	f := threads()
+	client := newTestClient(t, f)
+
+	titles, err := client.Items.AnnotateRoots(context.Background(), commentsOf(f, 4, 5, 6, 11, 21))
+	if err != nil {
+		t.Fatalf("AnnotateRoots() error = %v", err)
+	}
+
+	want := map[uint]string{4: "Story 1", 5: "Story 1", 6: "Story 1", 11: "Story 10", 21: ""}
+	if !maps.Equal(titles, want) {
+		t.Errorf("AnnotateRoots() = %v, want %v", titles, want)
+	}
+
+	// The chains of 4, 5 and 6 share the items 1, 2 and 3, which must be fetched only once.
+	for _, id := range []string{"1", "2", "3", "10", "20"} {
+		if n := f.count("/item/" + id + ".json"); n != 1 {
+			t.Errorf("item %s was fetched %d times, want 1", id, n)
+		}
+	}
+}
+
+func TestAnnotateRootsCycle(t *testing.T) {
+	f := &fixture{items: itemsOf(newComment(1, 2), newComment(2, 3), newComment(3, 2))}
+	client := newTestClient(t, f)
+
+	_, err := client.Items.AnnotateRoots(context.Background(), commentsOf(f, 1))
+
+	var itemErr *hn.ItemError
+	if !errors.As(err, &itemErr) || itemErr.ID != 1 || !errors.Is(err, hn.ErrCycle) {
+		t.Errorf("AnnotateRoots() error = %v, want an *ItemError for item 1 wrapping ErrCycle", err)
+	}
+}
+
+func TestCommentsWithContext(t *testing.T) {
+	f := threads()
+	f.users = map[string]hn.User{"pg": {ID: "pg", Submitted: []uint{11, 1, 6, 10, 4, 21}}}
+
+	client := newTestClient(t, f)
+
+	list, err := client.Users.CommentsWithContext(context.Background(), "pg", 0)
+	if err != nil {
+		t.Fatalf("CommentsWithContext() error = %v", err)
+	}
+
+	want := []hn.CommentContext{
+		{Comment: hn.ToComment(f.items[11]), RootID: 10, RootTitle: "Story 10"},
+		{Comment: hn.ToComment(f.items[6]), RootID: 1, RootTitle: "Story 1"},
+		{Comment: hn.ToComment(f.items[4]), RootID: 1, RootTitle: "Story 1"},
+		{Comment: hn.ToComment(f.items[21])},
+	}
+
+	if !slices.EqualFunc(list, want, equalCommentContext) {
+		t.Errorf("CommentsWithContext() = %+v, want %+v", list, want)
+	}
+}
+
+func TestCommentsWithContextLimit(t *testing.T) {
+	f := threads()
+	f.users = map[string]hn.User{"pg": {ID: "pg", Submitted: []uint{1, 10, 11, 6, 4}}}
+
+	client := newTestClient(t, f)
+
+	// The limit applies to the comments, not to the submissions, so the stories don't count.
+	list, err := client.Users.CommentsWithContext(context.Background(), "pg", 2)
+	if err != nil {
+		t.Fatalf("CommentsWithContext() error = %v", err)
+	}
+
+	want := []hn.CommentContext{
+		{Comment: hn.ToComment(f.items[11]), RootID: 10, RootTitle: "Story 10"},
+		{Comment: hn.ToComment(f.items[6]), RootID: 1, RootTitle: "Story 1"},
+	}
+
+	if !slices.EqualFunc(list, want, equalCommentContext) {
+		t.Errorf("CommentsWithContext() = %+v, want %+v", list, want)
+	}
+}
+
+func equalCommentContext(a, b hn.CommentContext) bool {
+	return a.Comment.ID == b.Comment.ID && a.RootID == b.RootID && a.RootTitle == b.RootTitle
+}
+
+// shape returns the IDs of the tree nodes in depth-first order, with the depth of each node.
+func shape(tree *hn.ThreadNode) [][2]uint {
+	var list [][2]uint
+
+	var visit func(node *hn.ThreadNode, depth uint)
+	visit = func(node *hn.ThreadNode, depth uint) {
+		list = append(list, [2]uint{node.Item.ID, depth})
+		for _, child := range node.Children {
+			visit(child, depth+1)
+		}
+	}
+
+	visit(tree, 0)
+
+	return list
+}
+
+func TestThread(t *testing.T) {
+	f := threads()
+	client := newTestClient(t, f)
+
+	tree, err := client.Items.Thread(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("Thread() error = %v", err)
+	}
+
+	want := [][2]uint{{1, 0}, {2, 1}, {3, 2}, {4, 3}, {5, 3}, {6, 1}}
+	if got := shape(tree); !slices.Equal(got, want) {
+		t.Errorf("Thread() = %v, want %v", got, want)
+	}
+
+	tree, err = client.Items.Thread(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("Thread(maxDepth = 2) error = %v", err)
+	}
+
+	want = [][2]uint{{1, 0}, {2, 1}, {3, 2}, {6, 1}}
+	if got := shape(tree); !slices.Equal(got, want) {
+		t.Errorf("Thread(maxDepth = 2) = %v, want %v", got, want)
+	}
+}
+
+func TestThreadCycleAndMissing(t *testing.T) {
+	// The comment 2 lists itself and the root as kids, and the kid 9 doesn't exist.
+	f := &fixture{items: itemsOf(newStory(1, 10, 2, 9), newComment(2, 1, 2, 1, 3), newComment(3, 2))}
+	client := newTestClient(t, f)
+
+	tree, err := client.Items.Thread(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("Thread() error = %v", err)
+	}
+
+	want := [][2]uint{{1, 0}, {2, 1}, {3, 2}}
+	if got := shape(tree); !slices.Equal(got, want) {
+		t.Errorf("Thread() = %v, want %v", got, want)
+	}
+}
+
+func TestThreadSkipDeadDeleted(t *testing.T) {
+	f := threads()
+
+	deleted := f.items[2]
+	deleted.Deleted = true
+	f.items[2] = deleted
+
+	dead := f.items[11]
+	dead.Dead = true
+	f.items[11] = dead
+
+	client := newTestClient(t, f)
+
+	tree, err := client.Items.Thread(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("Thread() error = %v", err)
+	}
+
+	// Without the option, the deleted comment and its replies are kept.
+	if got := len(shape(tree)); got != 6 {
+		t.Errorf("Thread() has %d nodes, want 6", got)
+	}
+
+	client = client.Clone(hn.WithSkipDeadDeleted())
+
+	tree, err = client.Items.Thread(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("Thread() error = %v", err)
+	}
+
+	want := [][2]uint{{1, 0}, {6, 1}}
+	if got := shape(tree); !slices.Equal(got, want) {
+		t.Errorf("Thread(WithSkipDeadDeleted) = %v, want %v", got, want)
+	}
+
+	var comments []uint
+
+	for c, err := range client.Items.Comments(context.Background(), 10, 0) {
+		if err != nil {
+			t.Fatalf("Comments() error = %v", err)
+		}
+
+		comments = append(comments, c.Comment.ID)
+	}
+
+	if len(comments) != 0 {
+		t.Errorf("Comments(WithSkipDeadDeleted) = %v, want no comments", comments)
+	}
+
+	if n, err := client.Items.CountComments(context.Background(), 1, 0); err != nil || n != 1 {
+		t.Errorf("CountComments(WithSkipDeadDeleted) = %d, %v, want 1", n, err)
+	}
+}
+
+func TestCancelBetweenStages(t *testing.T) {
+	tests := []struct {
+		name   string
+		after  string // the path of the last request of the first stage
+		run    func(ctx context.Context, client *hn.Client) error
+		unsent []string
+	}{
+		{
+			"thread root",
+			"/item/1.json",
+			func(ctx context.Context, client *hn.Client) error {
+				_, err := client.Items.Thread(ctx, 1, 0)
+				return err
+			},
+			[]string{"/item/2.json", "/item/6.json"},
+		},
+		{
+			"thread level",
+			"/item/2.json",
+			func(ctx context.Context, client *hn.Client) error {
+				_, err := client.Items.Thread(ctx, 1, 0)
+				return err
+			},
+			[]string{"/item/3.json"},
+		},
+		{
+			"user items",
+			"/user/pg.json",
+			func(ctx context.Context, client *hn.Client) error {
+				_, err := client.Users.Items(ctx, "pg", nil, 0)
+				return err
+			},
+			[]string{"/item/1.json", "/item/10.json"},
+		},
+		{
+			"top list",
+			"/topstories.json",
+			func(ctx context.Context, client *hn.Client) error {
+				_, err := client.Live.TopList(ctx, nil)
+				return err
+			},
+			[]string{"/item/1.json", "/item/10.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := threads()
+			// The story 1 has only one comment, so the first level of its thread is a single request.
+			f.items[1] = newStory(1, 10, 2)
+			f.users = map[string]hn.User{"pg": {ID: "pg", Submitted: []uint{1, 10}}}
+			f.lists = map[string][]uint{string(hn.CategoryTop): {1, 10}}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The hook is called after the response has been decoded, so the first stage succeeds.
+			client := newTestClient(t, f, hn.WithRequestHook(func(info hn.RequestInfo) {
+				if strings.HasSuffix(info.URL, tt.after) {
+					cancel()
+				}
+			}))
+
+			if err := tt.run(ctx, client); !errors.Is(err, context.Canceled) {
+				t.Fatalf("error = %v, want context.Canceled", err)
+			}
+
+			for _, path := range tt.unsent {
+				if n := f.count(path); n != 0 {
+					t.Errorf("got %d requests for %s after the cancellation, want 0", n, path)
+				}
+			}
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	f := threads()
+	client := newTestClient(t, f)
+
+	tree, err := client.Items.Thread(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("Thread() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		node *hn.ThreadNode
+		fn   func(depth int, item hn.Item) bool
+		want [][2]uint
+	}{
+		{
+			"all nodes",
+			tree,
+			func(int, hn.Item) bool { return true },
+			[][2]uint{{1, 0}, {2, 1}, {3, 2}, {4, 3}, {5, 3}, {6, 1}},
+		},
+		{
+			"pruned by depth",
+			tree,
+			func(depth int, _ hn.Item) bool { return depth < 2 },
+			[][2]uint{{1, 0}, {2, 1}, {3, 2}, {6, 1}},
+		},
+		{
+			"pruned subtree",
+			tree,
+			func(_ int, item hn.Item) bool { return item.ID != 2 },
+			[][2]uint{{1, 0}, {2, 1}, {6, 1}},
+		},
+		{
+			"root only",
+			tree,
+			func(int, hn.Item) bool { return false },
+			[][2]uint{{1, 0}},
+		},
+		{
+			"nil tree",
+			nil,
+			func(int, hn.Item) bool { return true },
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]uint
+
+			hn.Walk(tt.node, func(depth int, item hn.Item) bool {
+				got = append(got, [2]uint{item.ID, uint(depth)})
+				return tt.fn(depth, item)
+			})
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Walk() visited %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	f := threads()
+	// The comments 30 and 31 are the parents of each other.
+	f.items[30], f.items[31] = newComment(30, 31), newComment(31, 30)
+
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name    string
+		id      uint
+		want    []uint
+		wantErr error
+	}{
+		{"deep comment", 4, []uint{1, 2, 3}, nil},
+		{"top-level comment", 6, []uint{1}, nil},
+		{"story", 1, nil, nil},
+		{"missing parent", 21, nil, hn.ErrNotFound},
+		{"missing item", 99, nil, hn.ErrNotFound},
+		{"cycle", 30, nil, hn.ErrCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := client.Items.Ancestors(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Ancestors(%d) error = %v, want %v", tt.id, err, tt.wantErr)
+			}
+
+			if got := hn.IDsOf(chain); !slices.Equal(got, tt.want) {
+				t.Errorf("Ancestors(%d) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot(t *testing.T) {
+	f := threads()
+	f.items[30], f.items[31] = newComment(30, 31), newComment(31, 30)
+
+	poll := newItem(40, hn.PollType)
+	option := newItem(41, hn.PollOptionType)
+	option.Poll = 40
+	f.items[40], f.items[41] = poll, option
+
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name    string
+		id      uint
+		want    uint
+		wantErr error
+	}{
+		{"deep comment", 5, 1, nil},
+		{"comment of another story", 11, 10, nil},
+		{"story", 10, 10, nil},
+		{"poll option", 41, 41, nil},
+		{"missing parent", 21, 0, hn.ErrNotFound},
+		{"cycle", 31, 0, hn.ErrCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := client.Items.Root(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Root(%d) error = %v, want %v", tt.id, err, tt.wantErr)
+			}
+
+			if root.ID != tt.want {
+				t.Errorf("Root(%d) = %d, want %d", tt.id, root.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestThreadStats(t *testing.T) {
+	f := threads()
+	for id, by := range map[uint]string{2: "pg", 3: "dang", 4: "pg", 5: "tptacek", 6: "dang", 11: ""} {
+		item := f.items[id]
+		item.By = by
+		f.items[id] = item
+	}
+
+	client := newTestClient(t, f)
+
+	thread := func(id uint, maxDepth int) *hn.ThreadNode {
+		tree, err := client.Items.Thread(context.Background(), id, maxDepth)
+		if err != nil {
+			t.Fatalf("Thread(%d) error = %v", id, err)
+		}
+
+		return tree
+	}
+
+	tests := []struct {
+		name         string
+		node         *hn.ThreadNode
+		comments     int
+		participants []string
+		top          string
+		maxDepth     int
+		deepest      uint
+	}{
+		{"full thread", thread(1, 0), 5, []string{"pg", "dang", "tptacek"}, "pg", 3, 4},
+		{"limited depth", thread(1, 2), 3, []string{"pg", "dang"}, "dang", 2, 3},
+		{"comment root", thread(3, 0), 3, []string{"dang", "pg", "tptacek"}, "dang", 1, 4},
+		{"no authors", thread(10, 0), 1, nil, "", 1, 11},
+		{"no comments", &hn.ThreadNode{Item: f.items[1]}, 0, nil, "", 0, 0},
+		{"nil tree", nil, 0, nil, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := hn.ThreadStats(tt.node)
+
+			if stats.Comments != tt.comments {
+				t.Errorf("Comments = %d, want %d", stats.Comments, tt.comments)
+			}
+
+			if !slices.Equal(stats.Participants, tt.participants) {
+				t.Errorf("Participants = %v, want %v", stats.Participants, tt.participants)
+			}
+
+			if stats.TopCommenter != tt.top {
+				t.Errorf("TopCommenter = %q, want %q", stats.TopCommenter, tt.top)
+			}
+
+			if stats.MaxDepth != tt.maxDepth {
+				t.Errorf("MaxDepth = %d, want %d", stats.MaxDepth, tt.maxDepth)
+			}
+
+			var deepest uint
+			if stats.Deepest != nil {
+				deepest = stats.Deepest.Item.ID
+			}
+
+			if deepest != tt.deepest {
+				t.Errorf("Deepest = %d, want %d", deepest, tt.deepest)
+			}
+		})
+	}
+}
+
+func TestGetWithKids(t *testing.T) {
+	deleted := newComment(3, 1)
+	deleted.Deleted = true
+
+	bot := newComment(5, 1)
+	bot.By = "bot"
+
+	// The comment 9 is missing, and the reply 6 must not be fetched.
+	f := &fixture{items: itemsOf(newStory(1, 10, 2, 3, 4, 5, 9), newComment(2, 1, 6), deleted, newComment(4, 1), bot, newComment(6, 2))}
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name    string
+		id      uint
+		filter  func(hn.Item) bool
+		want    []uint
+		wantErr error
+	}{
+		{"all kids", 1, nil, []uint{2, 4, 5}, nil},
+		{"filtered kids", 1, func(item hn.Item) bool { return item.By != "bot" }, []uint{2, 4}, nil},
+		{"no kids", 4, nil, []uint{}, nil},
+		{"missing item", 7, nil, nil, hn.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, kids, err := client.Items.GetWithKids(context.Background(), tt.id, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetWithKids() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if item.ID != tt.id {
+				t.Errorf("GetWithKids() item = %d, want %d", item.ID, tt.id)
+			}
+
+			if got := hn.IDsOf(kids); !slices.Equal(got, tt.want) {
+				t.Errorf("GetWithKids() kids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if n := f.count("/item/6.json"); n != 0 {
+		t.Errorf("reply of a kid fetched %d times, want 0", n)
+	}
+}
+
+func TestCountComments(t *testing.T) {
+	f := threads()
+
+	// The deleted comment 3 is not counted, but its replies are.
+	deleted := f.items[3]
+	deleted.Deleted = true
+	f.items[3] = deleted
+
+	client := newTestClient(t, f)
+
+	tests := []struct {
+		name     string
+		id       uint
+		maxDepth int
+		want     int
+	}{
+		{"full thread", 1, 0, 4},
+		{"limited depth", 1, 2, 2},
+		{"one level", 1, 1, 2},
+		{"subthread", 2, 0, 3},
+		{"comment without replies", 6, 0, 1},
+		{"other story", 10, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Items.CountComments(context.Background(), tt.id, tt.maxDepth)
+			if err != nil {
+				t.Fatalf("CountComments(%d) error = %v", tt.id, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("CountComments(%d, %d) = %d, want %d", tt.id, tt.maxDepth, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := client.Items.CountComments(context.Background(), 20, 0); !errors.Is(err, hn.ErrNotFound) {
+		t.Errorf("CountComments(20) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       uint
+		maxDepth int
+		limit    int // number of comments consumed before breaking, 0 means all
+		want     [][2]uint
+		unloaded []uint
+	}{
+		{"all comments", 1, 0, 0, [][2]uint{{2, 1}, {3, 2}, {4, 3}, {5, 3}, {6, 1}}, nil},
+		{"limited depth", 1, 2, 0, [][2]uint{{2, 1}, {3, 2}, {6, 1}}, []uint{4, 5}},
+		{"comment root", 3, 0, 0, [][2]uint{{4, 1}, {5, 1}}, nil},
+		{"break", 1, 0, 2, [][2]uint{{2, 1}, {3, 2}}, []uint{4, 5}},
+		{"break at first", 1, 0, 1, [][2]uint{{2, 1}}, []uint{3, 4, 5}},
+		{"no replies", 6, 0, 0, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := threads()
+			client := newTestClient(t, f)
+
+			var got [][2]uint
+
+			for c, err := range client.Items.Comments(context.Background(), tt.id, tt.maxDepth) {
+				if err != nil {
+					t.Fatalf("Comments() error = %v", err)
+				}
+
+				got = append(got, [2]uint{c.Comment.ID, uint(c.Depth)})
+
+				if len(got) == tt.limit {
+					break
+				}
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Comments() = %v, want %v", got, tt.want)
+			}
+
+			// The replies are fetched lazily, so the comments after the break are not loaded.
+			for _, id := range tt.unloaded {
+				if n := f.count("/item/" + strconv.Itoa(int(id)) + ".json"); n != 0 {
+					t.Errorf("comment %d fetched %d times, want 0", id, n)
+				}
+			}
+		})
+	}
+
+	client := newTestClient(t, threads())
+
+	for _, err := range client.Items.Comments(context.Background(), 20, 0) {
+		if !errors.Is(err, hn.ErrNotFound) {
+			t.Errorf("Comments() of a missing item error = %v, want ErrNotFound", err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package hn
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Watch polls the updates at the given interval and sends the IDs of the items and profiles
+// that have changed since the previous poll. The first poll sends the whole snapshot, and
+// polls without any new changes send nothing. Errors of the polls are sent to the error channel.
+//
+// Watch stops polling and closes both channels when ctx is canceled.
+// It panics if interval is not positive, like time.NewTicker.
+func (s *LiveService) Watch(ctx context.Context, interval time.Duration) (<-chan Update, <-chan error) {
+	return s.WatchWithOptions(ctx, WatchOptions{Base: interval})
+}
+
+// WatchOptions contains the settings of adaptive polling of LiveService.WatchWithOptions.
+type WatchOptions struct {
+	// Base is the interval between the polls while the updates keep changing.
+	Base time.Duration
+
+	// Max is the maximum interval between the polls. The interval is doubled after each poll
+	// without any new changes (or with an error) until it reaches Max, and reset to Base
+	// when the changes appear. If Max is not greater than Base, the interval is always Base.
+	Max time.Duration
+
+	// Jitter is the fraction of the interval (from 0 to 1) by which each interval is randomly shortened,
+	// so multiple watchers don't poll at the same time. A value of 0 means no jitter,
+	// and the values outside of the range are clamped to it.
+	Jitter float64
+}
+
+// next returns the interval after the given one, depending on whether the last poll had any changes.
+func (o WatchOptions) next(interval time.Duration, changed bool) time.Duration {
+	if changed {
+		return o.Base
+	}
+
+	return max(min(2*interval, o.Max), o.Base)
+}
+
+// jitter returns the interval randomly shortened by up to Jitter of its length.
+func (o WatchOptions) jitter(interval time.Duration) time.Duration {
+	return time.Duration(float64(interval) * (1 - min(max(o.Jitter, 0), 1)*rand.Float64()))
+}
+
+// WatchWithOptions is like Watch, but polls the updates with an adaptive interval, increasing it
+// while nothing changes, so fewer requests are sent when the site is quiet. It panics if opts.Base is not positive.
+func (s *LiveService) WatchWithOptions(ctx context.Context, opts WatchOptions) (<-chan Update, <-chan error) {
+	if opts.Base <= 0 {
+		panic(fmt.Sprintf("hn: invalid watch interval: %v", opts.Base))
+	}
+
+	var (
+		updates = make(chan Update)
+		errs    = make(chan error)
+	)
+
+	go func() {
+		defer close(updates)
+		defer close(errs)
+
+		var (
+			prev     Update
+			interval = opts.Base
+		)
+
+		for {
+			update, err := s.Update(ctx)
+
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				interval = opts.next(interval, false)
+
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+			default:
+				changes := update.Diff(prev)
+				prev = update
+
+				changed := len(changes.Items) > 0 || len(changes.Profiles) > 0
+				interval = opts.next(interval, changed)
+
+				if changed {
+					select {
+					case updates <- changes:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			timer := time.NewTimer(opts.jitter(interval))
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return updates, errs
+}
+
+// Diff returns the items and profiles of the update that are not present in the previous update,
+// preserving their order and removing duplicates. If prev is empty, all items and profiles are returned.
+func (u Update) Diff(prev Update) Update {
+	return Update{
+		Items:    diff(u.Items, prev.Items),
+		Profiles: diff(u.Profiles, prev.Profiles),
+	}
+}
+
+// diff returns the unique values of curr that are not present in prev, preserving their order.
+func diff[T comparable](curr, prev []T) []T {
+	seen := make(map[T]bool, len(prev)+len(curr))
+	for _, v := range prev {
+		seen[v] = true
+	}
+
+	var values []T
+
+	for _, v := range curr {
+		if !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,46 @@
+package hn
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatchOptionsJitter(t *testing.T) {
+	tests := []struct {
+		jitter   float64
+		min, max time.Duration
+	}{
+		{0, time.Second, time.Second},
+		{0.5, 500 * time.Millisecond, time.Second},
+		{-1, time.Second, time.Second},
+		{3, 0, time.Second},
+	}
+
+	for _, tt := range tests {
+		opts := WatchOptions{Base: time.Second, Jitter: tt.jitter}
+
+		for range 100 {
+			if d := opts.jitter(time.Second); d < tt.min || d > tt.max {
+				t.Fatalf("jitter(1s) with Jitter = %v is %v, want from %v to %v", tt.jitter, d, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestWatchOptionsNext(t *testing.T) {
+	opts := WatchOptions{Base: time.Second, Max: 5 * time.Second}
+
+	var got []time.Duration
+
+	interval := opts.Base
+	for _, changed := range []bool{false, false, false, true, false} {
+		interval = opts.next(interval, changed)
+		got = append(got, interval)
+	}
+
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, time.Second, 2 * time.Second}
+	if !slices.Equal(got, want) {
+		t.Errorf("intervals = %v, want %v", got, want)
+	}
+}
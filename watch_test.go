@@ -0,0 +1,295 @@
+package hn_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hn "github.com/imotkin/hn-client"
+)
+
+func TestWatch(t *testing.T) {
+	polls := []hn.Update{
+		{Items: []uint{1, 2}, Profiles: []string{"pg"}},
+		{Items: []uint{1, 2}, Profiles: []string{"pg"}},
+		{Items: []uint{2, 3}, Profiles: []string{"pg", "dang"}},
+	}
+
+	var n atomic.Int32
+
+	f := &fixture{
+		handler: func(w http.ResponseWriter, r *http.Request) bool {
+			i := min(int(n.Add(1))-1, len(polls)-1)
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(polls[i])
+
+			return true
+		},
+	}
+
+	client := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, errs := client.Live.Watch(ctx, time.Millisecond)
+
+	want := []hn.Update{
+		{Items: []uint{1, 2}, Profiles: []string{"pg"}},
+		{Items: []uint{3}, Profiles: []string{"dang"}},
+	}
+
+	for _, w := range want {
+		select {
+		case got := <-updates:
+			if !slices.Equal(got.Items, w.Items) || !slices.Equal(got.Profiles, w.Profiles) {
+				t.Errorf("got update %+v, want %+v", got, w)
+			}
+		case err := <-errs:
+			t.Fatalf("Watch() error = %v", err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("no update received in time")
+		}
+	}
+
+	cancel()
+
+	// Both channels are closed after ctx is canceled.
+	for range updates {
+	}
+
+	for range errs {
+	}
+}
+
+func TestWatchWithOptionsAdaptive(t *testing.T) {
+	const (
+		base        = 25 * time.Millisecond
+		maxInterval = 4 * base
+	)
+
+	// The updates change at the first and the sixth poll, and stay the same at the other polls.
+	polls := []hn.Update{
+		{Items: []uint{1}},
+		{Items: []uint{1}},
+		{Items: []uint{1}},
+		{Items: []uint{1}},
+		{Items: []uint{1}},
+		{Items: []uint{2}, Profiles: []string{"pg"}},
+		{Items: []uint{2}, Profiles: []string{"pg"}},
+		{Items: []uint{2}, Profiles: []string{"pg"}},
+	}
+
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+
+	f := &fixture{
+		handler: func(w http.ResponseWriter, r *http.Request) bool {
+			mu.Lock()
+			times = append(times, time.Now())
+			i := min(len(times), len(polls)) - 1
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(polls[i])
+
+			return true
+		},
+	}
+
+	client := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, errs := client.Live.WatchWithOptions(ctx, hn.WatchOptions{Base: base, Max: maxInterval})
+
+	var (
+		received []hn.Update
+		done     = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+
+		for update := range updates {
+			received = append(received, update)
+		}
+	}()
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(times) >= len(polls)
+	})
+
+	cancel()
+
+	for err := range errs {
+		t.Errorf("WatchWithOptions() error = %v", err)
+	}
+
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// The interval doubles up to Max while nothing changes, and is reset to Base after a change.
+	want := []time.Duration{base, 2 * base, maxInterval, maxInterval, maxInterval, base, 2 * base}
+
+	for i, w := range want {
+		// The polls are late by the time of handling the previous response, but never early.
+		if gap := times[i+1].Sub(times[i]); gap < w || gap > w+2*base {
+			t.Errorf("interval after poll %d = %v, want %v", i+1, gap, w)
+		}
+	}
+
+	if len(received) != 2 {
+		t.Errorf("received %d updates, want 2", len(received))
+	}
+}
+
+func TestWatchInvalidInterval(t *testing.T) {
+	client := hn.NewClient(nil)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Watch(%v) didn't panic", interval)
+				}
+			}()
+
+			client.Live.Watch(context.Background(), interval)
+		}()
+	}
+}
+
+func TestUpdateDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		curr hn.Update
+		prev hn.Update
+		want hn.Update
+	}{
+		{
+			"overlap",
+			hn.Update{Items: []uint{5, 3, 4}, Profiles: []string{"pg", "dang"}},
+			hn.Update{Items: []uint{1, 3}, Profiles: []string{"pg"}},
+			hn.Update{Items: []uint{5, 4}, Profiles: []string{"dang"}},
+		},
+		{
+			"disjoint",
+			hn.Update{Items: []uint{3, 4}, Profiles: []string{"dang"}},
+			hn.Update{Items: []uint{1, 2}, Profiles: []string{"pg"}},
+			hn.Update{Items: []uint{3, 4}, Profiles: []string{"dang"}},
+		},
+		{
+			"empty previous",
+			hn.Update{Items: []uint{2, 1}, Profiles: []string{"pg"}},
+			hn.Update{},
+			hn.Update{Items: []uint{2, 1}, Profiles: []string{"pg"}},
+		},
+		{
+			"identical",
+			hn.Update{Items: []uint{1, 2}, Profiles: []string{"pg"}},
+			hn.Update{Items: []uint{1, 2}, Profiles: []string{"pg"}},
+			hn.Update{},
+		},
+		{
+			"duplicates",
+			hn.Update{Items: []uint{3, 1, 3}, Profiles: []string{"pg", "pg"}},
+			hn.Update{Items: []uint{1}},
+			hn.Update{Items: []uint{3}, Profiles: []string{"pg"}},
+		},
+		{"both empty", hn.Update{}, hn.Update{}, hn.Update{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.curr.Diff(tt.prev)
+
+			if !slices.Equal(got.Items, tt.want.Items) || !slices.Equal(got.Profiles, tt.want.Profiles) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    hn.Update
+		wantErr bool
+	}{
+		{"valid", `{"items":[1,2],"profiles":["pg"]}`, hn.Update{Items: []uint{1, 2}, Profiles: []string{"pg"}}, false},
+		{
+			"malformed item and profile",
+			`{"items":[1,-2,3],"profiles":["pg",42]}`,
+			hn.Update{Items: []uint{1, 3}, Profiles: []string{"pg"}, Skipped: 2},
+			false,
+		},
+		{
+			"too large item",
+			`{"items":[18446744073709551616,4],"profiles":[]}`,
+			hn.Update{Items: []uint{4}, Skipped: 1},
+			false,
+		},
+		{"null entries", `{"items":[null,5],"profiles":[null]}`, hn.Update{Items: []uint{5}, Skipped: 2}, false},
+		{"string item", `{"items":["6","x"]}`, hn.Update{Items: []uint{6}, Skipped: 1}, false},
+		{"missing fields", `{}`, hn.Update{}, false},
+		{"not an array", `{"items":1}`, hn.Update{}, true},
+		{"not an object", `[1,2]`, hn.Update{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The update is decoded into a used value, which must be reset.
+			got := hn.Update{Items: []uint{100}, Skipped: 10}
+
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error: %v", tt.json, err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !slices.Equal(got.Items, tt.want.Items) || !slices.Equal(got.Profiles, tt.want.Profiles) || got.Skipped != tt.want.Skipped {
+				t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.json, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("live update", func(t *testing.T) {
+		f := &fixture{
+			handler: func(w http.ResponseWriter, r *http.Request) bool {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"items":[1,"bad",2],"profiles":["pg",{}]}`))
+
+				return true
+			},
+		}
+		client := newTestClient(t, f)
+
+		update, err := client.Live.Update(context.Background())
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		if !slices.Equal(update.Items, []uint{1, 2}) || !slices.Equal(update.Profiles, []string{"pg"}) || update.Skipped != 2 {
+			t.Errorf("Update() = %+v, want items [1 2], profiles [pg] and 2 skipped entries", update)
+		}
+	})
+}